@@ -4,6 +4,7 @@ import (
 	"bufio"
 	_ "embed"
 	"errors"
+	"flag"
 	"fmt"
 	"net"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"radio-tui/internal/config"
 	"radio-tui/internal/ipc"
 	"radio-tui/internal/player"
+	"radio-tui/internal/playlist"
 	"radio-tui/internal/radio"
 	"radio-tui/internal/ui"
 )
@@ -27,12 +29,40 @@ const (
 	cmdPrev      = "PREV"
 	cmdQuit      = "QUIT"
 	cmdStatus    = "STATUS"
+	cmdPreset    = "PRESET"
+)
+
+var (
+	backendFlag = flag.String("backend", "", "comma-separated audio backends to try, e.g. mpv,go or null")
+	sinkFlag    = flag.String("sink", "", "write decoded audio to this WAV file instead of a sound card")
+	apiFlag     = flag.String("api", "", "comma-separated Radio Browser base URLs to use instead of the public mirrors")
 )
 
 func main() {
+	flag.Parse()
+	if flag.Arg(0) == "favorites" {
+		if err := runFavorites(flag.Args()[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	systray.Run(onReady, onExit)
 }
 
+// runFavorites imports or exports favorites without starting the tray.
+func runFavorites(args []string) error {
+	cfg := config.LoadConfig()
+	if *apiFlag != "" {
+		cfg.APIServers = strings.Split(*apiFlag, ",")
+	}
+	api, err := radio.NewClient("ValveFM/1.0 (terminal radio)", cfg.APIOptions()...)
+	if err != nil {
+		return err
+	}
+	return playlist.RunFavoritesCommand(args, api)
+}
+
 func onReady() {
 	if icon := trayIcon(); len(icon) > 0 {
 		systray.SetIcon(icon)
@@ -42,6 +72,8 @@ func onReady() {
 	mPlayPause := systray.AddMenuItem("Play/Pause", "Toggle playback")
 	mNext := systray.AddMenuItem("Next", "Next station")
 	mPrev := systray.AddMenuItem("Previous", "Previous station")
+	mPresets := systray.AddMenuItem("Presets", "Play a preset station")
+	presetItems := addPresetItems(mPresets)
 	systray.AddSeparator()
 	mQuit := systray.AddMenuItem("Quit", "Quit Valve FM")
 
@@ -74,10 +106,21 @@ func onReady() {
 		}
 	}()
 
+	for n, item := range presetItems {
+		go func() {
+			for range item.ClickedCh {
+				_, _ = sendCommand(fmt.Sprintf("%s %d", cmdPreset, n))
+			}
+		}()
+	}
+
 	go func() {
 		ticker := time.NewTicker(2 * time.Second)
 		defer ticker.Stop()
+		shownPresets := map[int]string{}
 		for range ticker.C {
+			// The TUI stores presets in the config file; pick up changes.
+			updatePresetTitles(presetItems, shownPresets)
 			status, err := sendCommand(cmdStatus)
 			if err != nil {
 				systray.SetTooltip("Valve FM (disconnected)")
@@ -113,17 +156,60 @@ func trayIcon() []byte {
 	return iconPNG
 }
 
+// addPresetItems adds one submenu item per preset button, keyed by number.
+func addPresetItems(parent *systray.MenuItem) map[int]*systray.MenuItem {
+	cfg := config.LoadConfig()
+	items := make(map[int]*systray.MenuItem, config.PresetCount)
+	for i := 1; i <= config.PresetCount; i++ {
+		n := i % config.PresetCount
+		items[n] = parent.AddSubMenuItem(presetTitle(cfg, n), fmt.Sprintf("Play preset %d", n))
+	}
+	return items
+}
+
+// updatePresetTitles renames the items whose preset changed since shown
+// was last updated.
+func updatePresetTitles(items map[int]*systray.MenuItem, shown map[int]string) {
+	cfg := config.LoadConfig()
+	for n, item := range items {
+		if title := presetTitle(cfg, n); title != shown[n] {
+			item.SetTitle(title)
+			shown[n] = title
+		}
+	}
+}
+
+func presetTitle(cfg config.AppConfig, n int) string {
+	station, ok := cfg.Presets[n]
+	if !ok {
+		return fmt.Sprintf("%d  (empty)", n)
+	}
+	return fmt.Sprintf("%d  %s", n, station.Name)
+}
+
 func runTUI() error {
-	api, err := radio.NewClient("ValveFM/1.0 (terminal radio)")
+	cfg := config.LoadConfig()
+	if *backendFlag != "" {
+		cfg.Backends = strings.Split(*backendFlag, ",")
+	}
+	cfg.UseSink(*sinkFlag, *backendFlag != "")
+	if *apiFlag != "" {
+		cfg.APIServers = strings.Split(*apiFlag, ",")
+	}
+
+	api, err := radio.NewClient("ValveFM/1.0 (terminal radio)", cfg.APIOptions()...)
 	if err != nil {
 		return err
 	}
 
-	playerInstance, playerErr := player.New()
+	playerInstance, playerErr := player.NewWithOptions(player.Options{
+		Priority: cfg.Backends,
+		Codecs:   cfg.CodecBackends,
+		SinkPath: cfg.SinkPath,
+	})
 	favorites, favErr := config.LoadFavorites()
-	cfg := config.LoadConfig()
 
-	model := ui.NewModel(api, playerInstance, favorites, playerErr, favErr, cfg.Theme)
+	model := ui.NewModel(api, playerInstance, favorites, playerErr, favErr, cfg)
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	_, err = program.Run()
 	return err
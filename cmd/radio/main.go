@@ -1,29 +1,57 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"radio-tui/internal/config"
 	"radio-tui/internal/player"
+	"radio-tui/internal/playlist"
 	"radio-tui/internal/radio"
 	"radio-tui/internal/ui"
 )
 
 func main() {
-	api, err := radio.NewClient("ValveFM/1.0 (terminal radio)")
+	backendFlag := flag.String("backend", "", "comma-separated audio backends to try, e.g. mpv,go or null")
+	sinkFlag := flag.String("sink", "", "write decoded audio to this WAV file instead of a sound card")
+	apiFlag := flag.String("api", "", "comma-separated Radio Browser base URLs to use instead of the public mirrors")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	if *backendFlag != "" {
+		cfg.Backends = strings.Split(*backendFlag, ",")
+	}
+	cfg.UseSink(*sinkFlag, *backendFlag != "")
+	if *apiFlag != "" {
+		cfg.APIServers = strings.Split(*apiFlag, ",")
+	}
+
+	api, err := radio.NewClient("ValveFM/1.0 (terminal radio)", cfg.APIOptions()...)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "radio api error:", err)
 		os.Exit(1)
 	}
 
-	playerInstance, playerErr := player.New()
+	if flag.Arg(0) == "favorites" {
+		if err := playlist.RunFavoritesCommand(flag.Args()[1:], api); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	playerInstance, playerErr := player.NewWithOptions(player.Options{
+		Priority: cfg.Backends,
+		Codecs:   cfg.CodecBackends,
+		SinkPath: cfg.SinkPath,
+	})
 	favorites, favErr := config.LoadFavorites()
-	cfg := config.LoadConfig()
 
-	model := ui.NewModel(api, playerInstance, favorites, playerErr, favErr, cfg.Theme)
+	model := ui.NewModel(api, playerInstance, favorites, playerErr, favErr, cfg)
 	program := tea.NewProgram(model, tea.WithAltScreen())
 	if _, err := program.Run(); err != nil {
 		fmt.Fprintln(os.Stderr, "error:", err)
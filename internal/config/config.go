@@ -5,13 +5,51 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+
+	"radio-tui/internal/radio"
 )
 
 // AppConfig holds application-level configuration.
 type AppConfig struct {
 	Theme string `json:"theme"`
+	// Backends lists audio backends in the order they are tried (e.g. ["mpv", "go"]).
+	Backends []string `json:"backends,omitempty"`
+	// CodecBackends overrides Backends per codec (e.g. {"aac": ["mpv", "ffplay"]}).
+	CodecBackends map[string][]string `json:"codec_backends,omitempty"`
+	// SinkPath is the WAV file written by the "file" backend.
+	SinkPath string `json:"sink_path,omitempty"`
+	// APIServers fixes the Radio Browser base URLs (e.g. a self-hosted mirror)
+	// and disables discovery of the public mirrors.
+	APIServers []string `json:"api_servers,omitempty"`
+	// APIHeaders are sent with every request to APIServers, e.g.
+	// {"Authorization": "Bearer ..."}. They require APIServers to be set.
+	APIHeaders map[string]string `json:"api_headers,omitempty"`
+	// DisableClickReporting stops playback from counting a click for the
+	// station on Radio Browser.
+	DisableClickReporting bool `json:"disable_click_reporting,omitempty"`
+	// Location is the default position for the nearby station list,
+	// e.g. {"lat": 52.52, "long": 13.405}.
+	Location *radio.Point `json:"location,omitempty"`
+	// NearbyRadiusKm limits the nearby list; 0 uses the API default of 100 km.
+	NearbyRadiusKm float64 `json:"nearby_radius_km,omitempty"`
+	// CheckStreams probes the stream of every listed station and greys out
+	// dead or slow ones; X toggles it for the session.
+	CheckStreams bool `json:"check_streams,omitempty"`
+	// PageSize is how many stations one list page holds; 0 uses 200.
+	PageSize int `json:"page_size,omitempty"`
+	// Presets maps preset buttons 0-9 to the station stored on them.
+	Presets map[int]radio.Station `json:"presets,omitempty"`
+	// DisableHistory stops logging what was listened to and when.
+	DisableHistory bool `json:"disable_history,omitempty"`
+	// HistoryDays drops listening history older than this; 0 keeps 90 days.
+	HistoryDays int `json:"history_days,omitempty"`
+	// HistorySessions caps the listening sessions kept; 0 keeps 1000.
+	HistorySessions int `json:"history_sessions,omitempty"`
 }
 
+// PresetCount is the number of preset buttons, numbered 0-9.
+const PresetCount = 10
+
 // LoadConfig reads the app config from ~/.config/valvefm/config.json.
 // Returns a zero-value AppConfig if the file does not exist.
 func LoadConfig() AppConfig {
@@ -30,9 +68,52 @@ func LoadConfig() AppConfig {
 	return cfg
 }
 
+// APIOptions builds the radio client options for the configured cache,
+// mirrors and headers.
+func (c AppConfig) APIOptions() []radio.Option {
+	var opts []radio.Option
+	if cacheDir, err := radio.DefaultCacheDir(); err == nil {
+		opts = append(opts, radio.WithCache(radio.NewCache(cacheDir)))
+	}
+	if len(c.APIServers) > 0 {
+		opts = append(opts, radio.WithServers(c.APIServers...))
+	}
+	if len(c.APIHeaders) > 0 {
+		opts = append(opts, radio.WithHeaders(c.APIHeaders))
+	}
+	return opts
+}
+
+// UseSink sends decoded audio to the WAV file at path. Unless backends were
+// chosen explicitly, the "file" backend becomes the only one tried.
+func (c *AppConfig) UseSink(path string, backendsChosen bool) {
+	if path == "" {
+		return
+	}
+	c.SinkPath = path
+	if !backendsChosen {
+		c.Backends = []string{"file"}
+	}
+}
+
 // SaveTheme persists the theme slug to the config file,
 // preserving any other fields that may exist.
 func SaveTheme(slug string) error {
+	return saveField("theme", slug)
+}
+
+// SaveLocation persists the nearby list position to the config file.
+func SaveLocation(point radio.Point) error {
+	return saveField("location", point)
+}
+
+// SavePresets persists the preset buttons to the config file.
+func SavePresets(presets map[int]radio.Station) error {
+	return saveField("presets", presets)
+}
+
+// saveField sets one top-level key in the config file, preserving the others.
+func saveField(key string, value any) error {
 	path, err := configPath()
 	if err != nil {
 		return err
@@ -52,7 +133,7 @@ func SaveTheme(slug string) error {
 		}
 	}
 
-	raw["theme"] = slug
+	raw[key] = value
 
 	out, err := json.MarshalIndent(raw, "", "  ")
 	if err != nil {
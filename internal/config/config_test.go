@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"radio-tui/internal/radio"
 )
 
 // testConfigDir creates a temporary config directory for testing.
@@ -202,3 +204,81 @@ func TestAppConfig_ExtraFields(t *testing.T) {
 		t.Errorf("Theme = %q, want %q", cfg.Theme, "nord")
 	}
 }
+
+func TestSaveLocation_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatalf("SaveTheme() error = %v", err)
+	}
+
+	want := radio.Point{Lat: 52.52, Long: 13.405}
+	if err := SaveLocation(want); err != nil {
+		t.Fatalf("SaveLocation() error = %v", err)
+	}
+
+	cfg := LoadConfig()
+	if cfg.Location == nil || *cfg.Location != want {
+		t.Errorf("Location = %+v, want %+v", cfg.Location, want)
+	}
+	if cfg.Theme != "nord" {
+		t.Errorf("Theme = %q, SaveLocation should preserve other fields", cfg.Theme)
+	}
+}
+
+func TestSavePresets_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatalf("SaveTheme() error = %v", err)
+	}
+
+	presets := map[int]radio.Station{
+		1: {UUID: "jazz", Name: "Jazz FM", URLResolved: "http://jazz.example.com/"},
+		0: {UUID: "news", Name: "News"},
+	}
+	if err := SavePresets(presets); err != nil {
+		t.Fatalf("SavePresets() error = %v", err)
+	}
+
+	cfg := LoadConfig()
+	if len(cfg.Presets) != 2 || cfg.Presets[1].Name != "Jazz FM" || cfg.Presets[1].URLResolved != "http://jazz.example.com/" || cfg.Presets[0].UUID != "news" {
+		t.Errorf("Presets = %+v", cfg.Presets)
+	}
+	if cfg.Theme != "nord" {
+		t.Errorf("Theme = %q, SavePresets should preserve other fields", cfg.Theme)
+	}
+}
+
+func TestAppConfig_UseSink(t *testing.T) {
+	cfg := AppConfig{Backends: []string{"mpv"}}
+	cfg.UseSink("", false)
+	if cfg.SinkPath != "" || cfg.Backends[0] != "mpv" {
+		t.Errorf("empty path should change nothing, got %+v", cfg)
+	}
+
+	cfg.UseSink("out.wav", false)
+	if cfg.SinkPath != "out.wav" || len(cfg.Backends) != 1 || cfg.Backends[0] != "file" {
+		t.Errorf("UseSink() = %+v, want only the file backend", cfg)
+	}
+
+	cfg = AppConfig{Backends: []string{"null", "file"}}
+	cfg.UseSink("out.wav", true)
+	if cfg.SinkPath != "out.wav" || len(cfg.Backends) != 2 {
+		t.Errorf("UseSink() should keep chosen backends, got %+v", cfg)
+	}
+}
+
+func TestAppConfig_APIOptions(t *testing.T) {
+	cfg := AppConfig{APIHeaders: map[string]string{"Authorization": "Bearer lab-token"}}
+	if _, err := radio.NewClient("TestApp/1.0", cfg.APIOptions()...); err == nil {
+		t.Error("headers without api_servers should be refused")
+	}
+
+	cfg.APIServers = []string{"http://localhost:8080"}
+	if _, err := radio.NewClient("TestApp/1.0", cfg.APIOptions()...); err != nil {
+		t.Errorf("NewClient() error = %v", err)
+	}
+}
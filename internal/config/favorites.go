@@ -3,11 +3,14 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"radio-tui/internal/radio"
 )
@@ -17,15 +20,48 @@ type Favorite struct {
 	Name    string `json:"name"`
 	Country string `json:"country"`
 	Tags    string `json:"tags"`
+	// Group is the named group the favorite is filed under, if any.
+	Group string `json:"group,omitempty"`
+	// Station is the most recent full record seen for the favorite.
+	Station *radio.Station `json:"station,omitempty"`
+	// Missing is set when Radio Browser no longer lists the station.
+	Missing     bool      `json:"missing,omitempty"`
+	RefreshedAt time.Time `json:"refreshed_at,omitzero"`
 }
 
+// Broken reports whether the station was last seen as broken or failing
+// its stream check.
+func (f Favorite) Broken() bool {
+	return f.Station != nil && (f.Station.IsBroken || f.Station.LastCheckFailed())
+}
+
+// RefreshResult summarises a Favorites.Refresh.
+type RefreshResult struct {
+	Updated int
+	Missing int
+	Broken  int
+}
+
+// favoritesVersion is the current favorites.json layout. Version 1 files
+// have no version field and no order; their favorites are sorted by name.
+const favoritesVersion = 2
+
 type Favorites struct {
 	mu    sync.Mutex
 	path  string
 	items map[string]Favorite
+	// order lists UUIDs in the user's order. Favorites missing from it,
+	// e.g. after loading a version 1 file, follow sorted by name.
+	order []string
+	// groups lists group names in the user's order.
+	groups []string
 }
 
 type favoritesFile struct {
+	Version int `json:"version"`
+	// Groups lists the group names in display order.
+	Groups []string `json:"groups,omitempty"`
+	// Stations are stored in the user's order.
 	Stations []Favorite `json:"stations"`
 }
 
@@ -34,7 +70,10 @@ func LoadFavorites() (*Favorites, error) {
 	if err != nil {
 		return nil, err
 	}
+	return loadFavoritesFrom(path)
+}
 
+func loadFavoritesFrom(path string) (*Favorites, error) {
 	favs := &Favorites{
 		path:  path,
 		items: map[string]Favorite{},
@@ -52,11 +91,21 @@ func LoadFavorites() (*Favorites, error) {
 	if err := json.Unmarshal(data, &stored); err != nil {
 		return nil, err
 	}
+	if stored.Version > favoritesVersion {
+		return nil, fmt.Errorf("favorites file version %d is newer than this version of ValveFM supports", stored.Version)
+	}
 	for _, fav := range stored.Stations {
-		if fav.UUID != "" {
-			favs.items[fav.UUID] = fav
+		if fav.UUID == "" {
+			continue
+		}
+		favs.items[fav.UUID] = fav
+		// Version 1 files were always shown sorted, so leave them unordered;
+		// the first save writes that order out as version 2.
+		if stored.Version >= 2 {
+			favs.order = append(favs.order, fav.UUID)
 		}
 	}
+	favs.groups = stored.Groups
 
 	return favs, nil
 }
@@ -74,13 +123,114 @@ func (f *Favorites) Toggle(station radio.Station) (bool, error) {
 		return false, f.saveLocked()
 	}
 
-	f.items[station.UUID] = Favorite{
+	f.putLocked(station)
+	return true, f.saveLocked()
+}
+
+// Add stores station as a favorite, replacing any earlier copy. It is used
+// for custom stations, which only exist in the favorites file.
+func (f *Favorites) Add(station radio.Station) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if station.UUID == "" {
+		return errors.New("station uuid is required")
+	}
+	f.putLocked(station)
+	return f.saveLocked()
+}
+
+// Merge adds the stations that are not favorites yet, saving once, and
+// returns how many were added. Existing favorites are left as they are.
+func (f *Favorites) Merge(stations []radio.Station) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	added := 0
+	for _, station := range stations {
+		if station.UUID == "" {
+			return 0, errors.New("station uuid is required")
+		}
+		if _, ok := f.items[station.UUID]; ok {
+			continue
+		}
+		f.putLocked(station)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, f.saveLocked()
+}
+
+// Get returns the favorite stored for uuid.
+func (f *Favorites) Get(uuid string) (Favorite, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fav, ok := f.items[uuid]
+	return fav, ok
+}
+
+// Refresh stores fresh station records for the favorites listed in checked.
+// Checked favorites absent from stations are flagged as missing rather than
+// removed, so a temporary API glitch never loses data. Custom stations are
+// never looked up and are left alone.
+func (f *Favorites) Refresh(checked []string, stations []radio.Station, now time.Time) (RefreshResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	found := make(map[string]radio.Station, len(stations))
+	for _, station := range stations {
+		found[station.UUID] = station
+	}
+
+	var result RefreshResult
+	for _, uuid := range checked {
+		fav, ok := f.items[uuid]
+		if !ok || radio.IsCustomUUID(uuid) {
+			continue
+		}
+		station, ok := found[uuid]
+		if !ok {
+			fav.Missing = true
+			result.Missing++
+			f.items[uuid] = fav
+			continue
+		}
+		group := fav.Group
+		fav = favoriteFromStation(station)
+		fav.Group = group
+		fav.RefreshedAt = now
+		f.items[uuid] = fav
+		result.Updated++
+		if fav.Broken() {
+			result.Broken++
+		}
+	}
+	return result, f.saveLocked()
+}
+
+// putLocked stores station, keeping the group and position of an earlier
+// copy. New favorites go to the end of the list.
+func (f *Favorites) putLocked(station radio.Station) {
+	fav := favoriteFromStation(station)
+	if old, ok := f.items[station.UUID]; ok {
+		fav.Group = old.Group
+	} else {
+		f.normalizeLocked()
+		f.order = append(f.order, station.UUID)
+	}
+	f.items[station.UUID] = fav
+}
+
+func favoriteFromStation(station radio.Station) Favorite {
+	return Favorite{
 		UUID:    station.UUID,
 		Name:    station.Name,
 		Country: station.Country,
 		Tags:    station.Tags,
+		Station: &station,
 	}
-	return true, f.saveLocked()
 }
 
 func (f *Favorites) IsFavorite(uuid string) bool {
@@ -96,23 +246,128 @@ func (f *Favorites) Count() int {
 	return len(f.items)
 }
 
+// List returns every favorite in the user's order.
 func (f *Favorites) List() []Favorite {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	return f.listLocked()
+}
+
+// ListGroup returns the favorites filed under group, in the user's order.
+func (f *Favorites) ListGroup(group string) []Favorite {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var list []Favorite
+	for _, fav := range f.listLocked() {
+		if fav.Group == group {
+			list = append(list, fav)
+		}
+	}
+	return list
+}
 
+// Groups returns the names of the groups that have favorites, in order.
+func (f *Favorites) Groups() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.groupsLocked()
+}
+
+// SetGroup files the favorite uuid under group, creating the group at the
+// end of the list if needed. An empty group ungroups the favorite.
+func (f *Favorites) SetGroup(uuid string, group string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	fav, ok := f.items[uuid]
+	if !ok {
+		return fmt.Errorf("station %s is not a favorite", uuid)
+	}
+	group = strings.TrimSpace(group)
+	f.groups = f.groupsLocked()
+	if group != "" && !slices.Contains(f.groups, group) {
+		f.groups = append(f.groups, group)
+	}
+	fav.Group = group
+	f.items[uuid] = fav
+	return f.saveLocked()
+}
+
+// Swap exchanges the positions of two favorites, which is how the list is
+// reordered one step at a time.
+func (f *Favorites) Swap(a string, b string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.normalizeLocked()
+	i, j := slices.Index(f.order, a), slices.Index(f.order, b)
+	if i < 0 || j < 0 {
+		return errors.New("both stations must be favorites")
+	}
+	f.order[i], f.order[j] = f.order[j], f.order[i]
+	return f.saveLocked()
+}
+
+func (f *Favorites) listLocked() []Favorite {
 	list := make([]Favorite, 0, len(f.items))
-	for _, fav := range f.items {
-		list = append(list, fav)
+	seen := make(map[string]bool, len(f.items))
+	for _, uuid := range f.order {
+		if fav, ok := f.items[uuid]; ok && !seen[uuid] {
+			seen[uuid] = true
+			list = append(list, fav)
+		}
 	}
-	sort.Slice(list, func(i, j int) bool {
-		ni := strings.ToLower(strings.TrimSpace(list[i].Name))
-		nj := strings.ToLower(strings.TrimSpace(list[j].Name))
+
+	var unordered []Favorite
+	for uuid, fav := range f.items {
+		if !seen[uuid] {
+			unordered = append(unordered, fav)
+		}
+	}
+	sort.Slice(unordered, func(i, j int) bool {
+		ni := strings.ToLower(strings.TrimSpace(unordered[i].Name))
+		nj := strings.ToLower(strings.TrimSpace(unordered[j].Name))
 		if ni == nj {
-			return list[i].UUID < list[j].UUID
+			return unordered[i].UUID < unordered[j].UUID
 		}
 		return ni < nj
 	})
-	return list
+	return append(list, unordered...)
+}
+
+// normalizeLocked makes order list exactly the current favorites.
+func (f *Favorites) normalizeLocked() {
+	list := f.listLocked()
+	f.order = make([]string, 0, len(list))
+	for _, fav := range list {
+		f.order = append(f.order, fav.UUID)
+	}
+}
+
+// groupsLocked keeps the known group order, drops empty groups and adds
+// groups only named by favorites at the end.
+func (f *Favorites) groupsLocked() []string {
+	used := map[string]bool{}
+	var named []string
+	for _, fav := range f.listLocked() {
+		if fav.Group != "" && !used[fav.Group] {
+			used[fav.Group] = true
+			named = append(named, fav.Group)
+		}
+	}
+	groups := make([]string, 0, len(named))
+	for _, group := range f.groups {
+		if used[group] && !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	for _, group := range named {
+		if !slices.Contains(groups, group) {
+			groups = append(groups, group)
+		}
+	}
+	return groups
 }
 
 func (f *Favorites) saveLocked() error {
@@ -120,12 +375,12 @@ func (f *Favorites) saveLocked() error {
 		return err
 	}
 
-	list := make([]Favorite, 0, len(f.items))
-	for _, fav := range f.items {
-		list = append(list, fav)
+	stored := favoritesFile{
+		Version:  favoritesVersion,
+		Groups:   f.groupsLocked(),
+		Stations: f.listLocked(),
 	}
-
-	data, err := json.MarshalIndent(favoritesFile{Stations: list}, "", "  ")
+	data, err := json.MarshalIndent(stored, "", "  ")
 	if err != nil {
 		return err
 	}
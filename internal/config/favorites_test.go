@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	"radio-tui/internal/radio"
 )
@@ -294,10 +295,229 @@ func TestFavorites_CountAndList(t *testing.T) {
 		t.Fatalf("Count() = %d, want 2", got)
 	}
 
+	// Favorites keep the order they were added in, not name order.
 	list := favs.List()
 	gotOrder := []string{list[0].Name, list[1].Name}
-	wantOrder := []string{"Alpha FM", "Zulu FM"}
+	wantOrder := []string{"Zulu FM", "Alpha FM"}
 	if !reflect.DeepEqual(gotOrder, wantOrder) {
 		t.Fatalf("List() order = %v, want %v", gotOrder, wantOrder)
 	}
 }
+
+func TestFavorites_Refresh(t *testing.T) {
+	favs := newTestFavorites(t)
+	for _, s := range []radio.Station{
+		{UUID: "live", Name: "Old Name"},
+		{UUID: "broken", Name: "Broken FM"},
+		{UUID: "gone", Name: "Gone FM"},
+		{UUID: "unchecked", Name: "Added Later"},
+	} {
+		if _, err := favs.Toggle(s); err != nil {
+			t.Fatalf("Toggle() error = %v", err)
+		}
+	}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	fresh := []radio.Station{
+		{UUID: "live", Name: "New Name", Bitrate: 128, URLResolved: "http://live.example.com"},
+		{UUID: "broken", Name: "Broken FM", LastCheckOK: radio.FlagFalse},
+	}
+	result, err := favs.Refresh([]string{"live", "broken", "gone"}, fresh, now)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if result != (RefreshResult{Updated: 2, Missing: 1, Broken: 1}) {
+		t.Errorf("Refresh() = %+v", result)
+	}
+
+	loaded := &Favorites{path: favs.path, items: map[string]Favorite{}}
+	data, err := os.ReadFile(favs.path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var stored favoritesFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for _, fav := range stored.Stations {
+		loaded.items[fav.UUID] = fav
+	}
+
+	live, _ := loaded.Get("live")
+	if live.Name != "New Name" || live.Station == nil || live.Station.Bitrate != 128 || !live.RefreshedAt.Equal(now) {
+		t.Errorf("live favorite = %+v", live)
+	}
+	if broken, _ := loaded.Get("broken"); !broken.Broken() {
+		t.Error("broken favorite should be flagged")
+	}
+	if gone, ok := loaded.Get("gone"); !ok || !gone.Missing || gone.Name != "Gone FM" {
+		t.Errorf("missing favorite should be kept and flagged, got %+v", gone)
+	}
+	if unchecked, _ := loaded.Get("unchecked"); unchecked.Missing {
+		t.Error("favorites outside the checked set should not be flagged")
+	}
+}
+
+func TestFavorites_AddCustomStation(t *testing.T) {
+	favs := newTestFavorites(t)
+	station, err := radio.NewStation{Name: "Office Radio", URL: "http://intranet.example.com/live"}.CustomStation()
+	if err != nil {
+		t.Fatalf("CustomStation() error = %v", err)
+	}
+	if err := favs.Add(station); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	result, err := favs.Refresh([]string{station.UUID}, nil, time.Now())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if result != (RefreshResult{}) {
+		t.Errorf("Refresh() = %+v, custom stations should be skipped", result)
+	}
+
+	loaded, err := loadFavoritesFrom(favs.path)
+	if err != nil {
+		t.Fatalf("loadFavoritesFrom() error = %v", err)
+	}
+	fav, ok := loaded.Get(station.UUID)
+	if !ok || fav.Missing || fav.Station == nil || fav.Station.URLResolved != station.URL {
+		t.Errorf("custom favorite = %+v", fav)
+	}
+}
+
+func TestFavorites_Merge(t *testing.T) {
+	favs := newTestFavorites(t)
+	if _, err := favs.Toggle(radio.Station{UUID: "kept", Name: "Original"}); err != nil {
+		t.Fatalf("Toggle() error = %v", err)
+	}
+
+	added, err := favs.Merge([]radio.Station{
+		{UUID: "kept", Name: "Renamed"},
+		{UUID: "new", Name: "New"},
+	})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if added != 1 || favs.Count() != 2 {
+		t.Errorf("Merge() added %d, count %d; want 1 and 2", added, favs.Count())
+	}
+	if fav, _ := favs.Get("kept"); fav.Name != "Original" {
+		t.Errorf("existing favorite was replaced: %+v", fav)
+	}
+
+	loaded, err := loadFavoritesFrom(favs.path)
+	if err != nil {
+		t.Fatalf("loadFavoritesFrom() error = %v", err)
+	}
+	if !loaded.IsFavorite("new") {
+		t.Error("merged favorite was not saved")
+	}
+}
+
+func TestFavorites_MigratesVersion1File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	v1 := `{"stations":[{"uuid":"2","name":"Zulu FM"},{"uuid":"1","name":"alpha FM"}]}`
+	if err := os.WriteFile(path, []byte(v1), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	favs, err := loadFavoritesFrom(path)
+	if err != nil {
+		t.Fatalf("loadFavoritesFrom() error = %v", err)
+	}
+	// Version 1 lists were shown sorted by name; that becomes the order.
+	if list := favs.List(); list[0].UUID != "1" || list[1].UUID != "2" {
+		t.Fatalf("List() = %+v, want name order", list)
+	}
+	if _, err := favs.Toggle(radio.Station{UUID: "3", Name: "Beta FM"}); err != nil {
+		t.Fatalf("Toggle() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var stored favoritesFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	var order []string
+	for _, fav := range stored.Stations {
+		order = append(order, fav.UUID)
+	}
+	if stored.Version != favoritesVersion || !reflect.DeepEqual(order, []string{"1", "2", "3"}) {
+		t.Errorf("saved version %d, order %v", stored.Version, order)
+	}
+}
+
+func TestFavorites_RejectsNewerVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	if err := os.WriteFile(path, []byte(`{"version":99,"stations":[]}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := loadFavoritesFrom(path); err == nil {
+		t.Error("a newer file version should not be loaded and overwritten")
+	}
+}
+
+func TestFavorites_GroupsAndOrder(t *testing.T) {
+	favs := newTestFavorites(t)
+	for _, uuid := range []string{"a", "b", "c", "d"} {
+		if _, err := favs.Toggle(radio.Station{UUID: uuid, Name: uuid}); err != nil {
+			t.Fatalf("Toggle() error = %v", err)
+		}
+	}
+	for _, set := range []struct{ uuid, group string }{{"a", "Jazz"}, {"c", "Jazz"}, {"d", " News "}} {
+		if err := favs.SetGroup(set.uuid, set.group); err != nil {
+			t.Fatalf("SetGroup() error = %v", err)
+		}
+	}
+	if err := favs.Swap("c", "a"); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+
+	uuids := func(list []Favorite) []string {
+		var ids []string
+		for _, fav := range list {
+			ids = append(ids, fav.UUID)
+		}
+		return ids
+	}
+	if got := uuids(favs.ListGroup("Jazz")); !reflect.DeepEqual(got, []string{"c", "a"}) {
+		t.Errorf("ListGroup(Jazz) = %v", got)
+	}
+	if got := uuids(favs.ListGroup("News")); !reflect.DeepEqual(got, []string{"d"}) {
+		t.Errorf("ListGroup(News) = %v", got)
+	}
+
+	// Refreshing a station keeps its group.
+	if _, err := favs.Refresh([]string{"c"}, []radio.Station{{UUID: "c", Name: "C FM"}}, time.Now()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	loaded, err := loadFavoritesFrom(favs.path)
+	if err != nil {
+		t.Fatalf("loadFavoritesFrom() error = %v", err)
+	}
+	if got := uuids(loaded.List()); !reflect.DeepEqual(got, []string{"c", "b", "a", "d"}) {
+		t.Errorf("List() after reload = %v", got)
+	}
+	if got := loaded.Groups(); !reflect.DeepEqual(got, []string{"Jazz", "News"}) {
+		t.Errorf("Groups() = %v", got)
+	}
+	if fav, _ := loaded.Get("c"); fav.Group != "Jazz" || fav.Name != "C FM" {
+		t.Errorf("refreshed favorite = %+v", fav)
+	}
+
+	// Emptying a group removes it.
+	if err := loaded.SetGroup("d", ""); err != nil {
+		t.Fatalf("SetGroup() error = %v", err)
+	}
+	if got := loaded.Groups(); !reflect.DeepEqual(got, []string{"Jazz"}) {
+		t.Errorf("Groups() = %v after emptying News", got)
+	}
+	if err := loaded.SetGroup("missing", "Jazz"); err == nil {
+		t.Error("SetGroup() on a non-favorite should fail")
+	}
+}
@@ -0,0 +1,444 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"radio-tui/internal/radio"
+)
+
+// Retention limits of the listening history when the config sets none.
+const (
+	DefaultHistoryDays     = 90
+	DefaultHistorySessions = 1000
+)
+
+// historyTitles caps the titles kept per session, so a long night on one
+// station does not grow the log without bound.
+const historyTitles = 200
+
+// HistoryOptions bounds how much listening history is kept. Sessions that
+// ended more than MaxAge ago, and the oldest sessions beyond MaxSessions,
+// are dropped when the history is loaded or grows.
+type HistoryOptions struct {
+	MaxAge      time.Duration
+	MaxSessions int
+}
+
+// HistoryOptions returns the retention limits set in the config.
+func (c AppConfig) HistoryOptions() HistoryOptions {
+	days := c.HistoryDays
+	if days <= 0 {
+		days = DefaultHistoryDays
+	}
+	sessions := c.HistorySessions
+	if sessions <= 0 {
+		sessions = DefaultHistorySessions
+	}
+	return HistoryOptions{
+		MaxAge:      time.Duration(days) * 24 * time.Hour,
+		MaxSessions: sessions,
+	}
+}
+
+// HistorySession is one stretch of listening to a station.
+type HistorySession struct {
+	Station radio.Station
+	Start   time.Time
+	// Stop is zero while the session is still playing.
+	Stop time.Time
+	// Titles are the ICY stream titles seen, oldest first.
+	Titles []string
+}
+
+// Duration is how long the session lasted, up to now if it is still
+// playing.
+func (s HistorySession) Duration(now time.Time) time.Duration {
+	stop := s.Stop
+	if stop.IsZero() {
+		stop = now
+	}
+	return max(stop.Sub(s.Start), 0)
+}
+
+// StationStats sums up the listening history of one station.
+type StationStats struct {
+	Station    radio.Station
+	Sessions   int
+	Total      time.Duration
+	LastPlayed time.Time
+	// LastTitle is the most recent stream title seen on the station.
+	LastTitle string
+}
+
+// History is the listening log, kept as an append-only file of JSON lines
+// in ~/.config/valvefm/history.jsonl. Each line is a start, title or stop
+// event; the file is only rewritten to apply the retention limits.
+type History struct {
+	mu       sync.Mutex
+	path     string
+	opts     HistoryOptions
+	sessions []HistorySession
+	// open is the index of the session playing now, or -1.
+	open int
+}
+
+type historyEvent struct {
+	Event   string         `json:"event"`
+	Time    time.Time      `json:"time"`
+	UUID    string         `json:"uuid"`
+	Station *radio.Station `json:"station,omitempty"`
+	Title   string         `json:"title,omitempty"`
+}
+
+const (
+	historyStart = "start"
+	historyTitle = "title"
+	historyStop  = "stop"
+)
+
+// LoadHistory reads the listening history and applies the retention
+// limits.
+func LoadHistory(opts HistoryOptions) (*History, error) {
+	path, err := historyPath()
+	if err != nil {
+		return nil, err
+	}
+	return loadHistoryFrom(path, opts, time.Now())
+}
+
+func loadHistoryFrom(path string, opts HistoryOptions, now time.Time) (*History, error) {
+	history := &History{path: path, opts: opts, open: -1}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return history, nil
+		}
+		return nil, err
+	}
+
+	// lastSeen tracks the latest event of the open session, which is when
+	// it ended if the app quit without logging a stop.
+	var lastSeen time.Time
+	closeOpen := func() {
+		if history.open >= 0 && history.sessions[history.open].Stop.IsZero() {
+			history.sessions[history.open].Stop = lastSeen
+		}
+		history.open = -1
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var event historyEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.UUID == "" {
+			// A crash mid-write leaves a torn last line; skip it.
+			continue
+		}
+		switch event.Event {
+		case historyStart:
+			closeOpen()
+			station := radio.Station{UUID: event.UUID}
+			if event.Station != nil {
+				station = *event.Station
+				station.UUID = event.UUID
+			}
+			history.sessions = append(history.sessions, HistorySession{Station: station, Start: event.Time})
+			history.open = len(history.sessions) - 1
+			lastSeen = event.Time
+		case historyTitle:
+			if history.open >= 0 && history.sessions[history.open].Station.UUID == event.UUID {
+				history.sessions[history.open].addTitle(event.Title)
+				lastSeen = event.Time
+			}
+		case historyStop:
+			if history.open >= 0 && history.sessions[history.open].Station.UUID == event.UUID {
+				history.sessions[history.open].Stop = event.Time
+				history.open = -1
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	closeOpen()
+
+	// Appending after a torn line would corrupt the next event too.
+	torn := len(data) > 0 && data[len(data)-1] != '\n'
+	if history.pruneLocked(now) || torn {
+		if err := history.compactLocked(); err != nil {
+			return nil, err
+		}
+	}
+	return history, nil
+}
+
+func (s *HistorySession) addTitle(title string) bool {
+	if title == "" || (len(s.Titles) > 0 && s.Titles[len(s.Titles)-1] == title) {
+		return false
+	}
+	if len(s.Titles) >= historyTitles {
+		s.Titles = s.Titles[1:]
+	}
+	s.Titles = append(s.Titles, title)
+	return true
+}
+
+// Start logs that station started playing at now, ending the session
+// before it.
+func (h *History) Start(station radio.Station, now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if station.UUID == "" {
+		return errors.New("station uuid is required")
+	}
+	events := h.stopLocked(now)
+	snapshot := station
+	events = append(events, historyEvent{Event: historyStart, Time: now, UUID: station.UUID, Station: &snapshot})
+	h.sessions = append(h.sessions, HistorySession{Station: station, Start: now})
+	h.open = len(h.sessions) - 1
+
+	if h.pruneLocked(now) {
+		return h.compactLocked()
+	}
+	return h.appendLocked(events...)
+}
+
+// Title logs a stream title seen on the station playing now. Repeats of
+// the last title and titles for any other station are ignored.
+func (h *History) Title(uuid, title string, now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.open < 0 || h.sessions[h.open].Station.UUID != uuid {
+		return nil
+	}
+	if !h.sessions[h.open].addTitle(title) {
+		return nil
+	}
+	return h.appendLocked(historyEvent{Event: historyTitle, Time: now, UUID: uuid, Title: title})
+}
+
+// Stop logs that playback stopped at now.
+func (h *History) Stop(now time.Time) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	events := h.stopLocked(now)
+	if len(events) == 0 {
+		return nil
+	}
+	return h.appendLocked(events...)
+}
+
+func (h *History) stopLocked(now time.Time) []historyEvent {
+	if h.open < 0 {
+		return nil
+	}
+	session := &h.sessions[h.open]
+	session.Stop = now
+	h.open = -1
+	return []historyEvent{{Event: historyStop, Time: now, UUID: session.Station.UUID}}
+}
+
+// Sessions returns the logged sessions, newest first.
+func (h *History) Sessions() []HistorySession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sessions := make([]HistorySession, 0, len(h.sessions))
+	for i := len(h.sessions) - 1; i >= 0; i-- {
+		session := h.sessions[i]
+		session.Titles = slices.Clone(session.Titles)
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+// Count returns the number of logged sessions.
+func (h *History) Count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.sessions)
+}
+
+// Recent returns up to limit stations, most recently played first, each
+// listed once. A limit of zero or less returns them all.
+func (h *History) Recent(limit int) []radio.Station {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	seen := map[string]bool{}
+	stations := []radio.Station{}
+	for i := len(h.sessions) - 1; i >= 0; i-- {
+		station := h.sessions[i].Station
+		if seen[station.UUID] {
+			continue
+		}
+		seen[station.UUID] = true
+		stations = append(stations, station)
+		if limit > 0 && len(stations) == limit {
+			break
+		}
+	}
+	return stations
+}
+
+// Stats sums up the history per station, most listened first. The session
+// playing now counts up to now.
+func (h *History) Stats(now time.Time) []StationStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	byUUID := map[string]*StationStats{}
+	for _, session := range h.sessions {
+		stats, ok := byUUID[session.Station.UUID]
+		if !ok {
+			stats = &StationStats{}
+			byUUID[session.Station.UUID] = stats
+		}
+		// Later sessions carry the newer station snapshot.
+		stats.Station = session.Station
+		stats.Sessions++
+		stats.Total += session.Duration(now)
+		stats.LastPlayed = session.Start
+		if len(session.Titles) > 0 {
+			stats.LastTitle = session.Titles[len(session.Titles)-1]
+		}
+	}
+
+	all := make([]StationStats, 0, len(byUUID))
+	for _, stats := range byUUID {
+		all = append(all, *stats)
+	}
+	slices.SortFunc(all, func(a, b StationStats) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return b.LastPlayed.Compare(a.LastPlayed)
+	})
+	return all
+}
+
+// StationStats returns the history of one station.
+func (h *History) StationStats(uuid string, now time.Time) (StationStats, bool) {
+	for _, stats := range h.Stats(now) {
+		if stats.Station.UUID == uuid {
+			return stats, true
+		}
+	}
+	return StationStats{}, false
+}
+
+// Clear forgets the whole history and removes its file.
+func (h *History) Clear() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sessions = nil
+	h.open = -1
+	if err := os.Remove(h.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// pruneLocked drops sessions outside the retention limits and reports
+// whether any were dropped. The session playing now is always kept.
+func (h *History) pruneLocked(now time.Time) bool {
+	drop := 0
+	if h.opts.MaxAge > 0 {
+		cutoff := now.Add(-h.opts.MaxAge)
+		for drop < len(h.sessions) && drop != h.open {
+			session := h.sessions[drop]
+			if !session.Stop.IsZero() && session.Stop.After(cutoff) {
+				break
+			}
+			drop++
+		}
+	}
+	if h.opts.MaxSessions > 0 {
+		drop = max(drop, len(h.sessions)-h.opts.MaxSessions)
+		if h.open >= 0 {
+			drop = min(drop, h.open)
+		}
+	}
+	if drop == 0 {
+		return false
+	}
+	h.sessions = slices.Delete(h.sessions, 0, drop)
+	if h.open >= 0 {
+		h.open -= drop
+	}
+	return true
+}
+
+// compactLocked rewrites the log from the sessions kept.
+func (h *History) compactLocked() error {
+	var events []historyEvent
+	for i, session := range h.sessions {
+		station := session.Station
+		uuid := station.UUID
+		events = append(events, historyEvent{Event: historyStart, Time: session.Start, UUID: uuid, Station: &station})
+		for _, title := range session.Titles {
+			events = append(events, historyEvent{Event: historyTitle, Time: session.Start, UUID: uuid, Title: title})
+		}
+		if i != h.open {
+			events = append(events, historyEvent{Event: historyStop, Time: session.Stop, UUID: uuid})
+		}
+	}
+	data, err := encodeHistory(events)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+func (h *History) appendLocked(events ...historyEvent) error {
+	data, err := encodeHistory(events)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func encodeHistory(events []historyEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func historyPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "valvefm", "history.jsonl"), nil
+}
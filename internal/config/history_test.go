@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"radio-tui/internal/radio"
+)
+
+func TestHistory_LogAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	opts := HistoryOptions{MaxAge: 30 * 24 * time.Hour, MaxSessions: 100}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	history, err := loadHistoryFrom(path, opts, now)
+	if err != nil {
+		t.Fatalf("loadHistoryFrom() error = %v", err)
+	}
+	jazz := radio.Station{UUID: "jazz", Name: "Jazz FM"}
+	news := radio.Station{UUID: "news", Name: "News Talk"}
+
+	steps := []func() error{
+		func() error { return history.Start(jazz, now) },
+		func() error { return history.Title("jazz", "Miles Davis - So What", now.Add(time.Minute)) },
+		func() error { return history.Title("jazz", "Miles Davis - So What", now.Add(2*time.Minute)) },
+		func() error { return history.Title("news", "ignored, not playing", now.Add(2*time.Minute)) },
+		// Switching stations ends the previous session.
+		func() error { return history.Start(news, now.Add(time.Hour)) },
+		func() error { return history.Stop(now.Add(90 * time.Minute)) },
+		func() error { return history.Start(jazz, now.Add(2*time.Hour)) },
+		func() error { return history.Stop(now.Add(3 * time.Hour)) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d error = %v", i, err)
+		}
+	}
+
+	reloaded, err := loadHistoryFrom(path, opts, now.Add(4*time.Hour))
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	sessions := reloaded.Sessions()
+	if len(sessions) != 3 || sessions[0].Station.UUID != "jazz" || sessions[2].Station.Name != "Jazz FM" {
+		t.Fatalf("Sessions() = %+v", sessions)
+	}
+	if want := []string{"Miles Davis - So What"}; !reflect.DeepEqual(sessions[2].Titles, want) {
+		t.Errorf("Titles = %q, want %q", sessions[2].Titles, want)
+	}
+
+	stats := reloaded.Stats(now.Add(4 * time.Hour))
+	if len(stats) != 2 {
+		t.Fatalf("Stats() = %+v", stats)
+	}
+	if stats[0].Station.UUID != "jazz" || stats[0].Total != 2*time.Hour || stats[0].Sessions != 2 || stats[0].LastTitle != "Miles Davis - So What" {
+		t.Errorf("Stats()[0] = %+v", stats[0])
+	}
+	if stats[1].Station.UUID != "news" || stats[1].Total != 30*time.Minute {
+		t.Errorf("Stats()[1] = %+v", stats[1])
+	}
+
+	recent := reloaded.Recent(0)
+	if len(recent) != 2 || recent[0].UUID != "jazz" || recent[1].UUID != "news" {
+		t.Errorf("Recent() = %+v", recent)
+	}
+}
+
+func TestHistory_UnstoppedSessionEndsAtLastEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	history, _ := loadHistoryFrom(path, HistoryOptions{}, now)
+	if err := history.Start(radio.Station{UUID: "jazz"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Title("jazz", "Song", now.Add(10*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	// Simulate a crash that tore the next line.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	f.WriteString(`{"event":"stop","ti`)
+	f.Close()
+
+	reloaded, err := loadHistoryFrom(path, HistoryOptions{}, now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	sessions := reloaded.Sessions()
+	if len(sessions) != 1 || !sessions[0].Stop.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("Sessions() = %+v", sessions)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), `"ti`+"\n") || !strings.HasSuffix(string(data), "\n") {
+		t.Errorf("torn line was not compacted away:\n%s", data)
+	}
+}
+
+func TestHistory_Retention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := HistoryOptions{MaxAge: 7 * 24 * time.Hour, MaxSessions: 3}
+
+	history, _ := loadHistoryFrom(path, HistoryOptions{}, now)
+	start := now.Add(-10 * 24 * time.Hour)
+	for i := range 6 {
+		at := start.Add(time.Duration(i) * 24 * time.Hour)
+		station := radio.Station{UUID: string(rune('a' + i))}
+		if err := history.Start(station, at); err != nil {
+			t.Fatal(err)
+		}
+		if err := history.Stop(at.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Sessions a-c ended over a week ago; of d-f all three fit the cap.
+	reloaded, err := loadHistoryFrom(path, opts, now)
+	if err != nil {
+		t.Fatalf("reload error = %v", err)
+	}
+	if got := uuids(reloaded.Recent(0)); got != "fed" {
+		t.Errorf("after age limit Recent() = %q, want fed", got)
+	}
+
+	// Growing past the cap drops the oldest session but keeps the new one.
+	if err := reloaded.Start(radio.Station{UUID: "g"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if got := uuids(reloaded.Recent(0)); got != "gfe" {
+		t.Errorf("after session cap Recent() = %q, want gfe", got)
+	}
+	again, err := loadHistoryFrom(path, opts, now.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := uuids(again.Recent(0)); got != "gfe" {
+		t.Errorf("compacted file Recent() = %q, want gfe", got)
+	}
+}
+
+func TestHistory_Clear(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Now()
+	history, _ := loadHistoryFrom(path, HistoryOptions{}, now)
+	if err := history.Start(radio.Station{UUID: "a"}, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := history.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if history.Count() != 0 {
+		t.Errorf("Count() = %d after Clear()", history.Count())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("history file still exists: %v", err)
+	}
+	// A stop after clearing logs nothing for the forgotten session.
+	if err := history.Stop(now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Stop() after Clear() recreated the file")
+	}
+}
+
+func TestAppConfig_HistoryOptions(t *testing.T) {
+	got := AppConfig{}.HistoryOptions()
+	if got.MaxAge != DefaultHistoryDays*24*time.Hour || got.MaxSessions != DefaultHistorySessions {
+		t.Errorf("default HistoryOptions() = %+v", got)
+	}
+	got = AppConfig{HistoryDays: 7, HistorySessions: 50}.HistoryOptions()
+	if got.MaxAge != 7*24*time.Hour || got.MaxSessions != 50 {
+		t.Errorf("HistoryOptions() = %+v", got)
+	}
+}
+
+func uuids(stations []radio.Station) string {
+	var b strings.Builder
+	for _, station := range stations {
+		b.WriteString(station.UUID)
+	}
+	return b.String()
+}
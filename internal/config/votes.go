@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// VoteInterval is how long after voting for a station another vote for it is
+// refused locally, so restarts or repeated key presses never double-vote.
+const VoteInterval = 24 * time.Hour
+
+// Votes remembers when each station was last voted for.
+type Votes struct {
+	mu    sync.Mutex
+	path  string
+	items map[string]time.Time
+}
+
+type votesFile struct {
+	Votes map[string]time.Time `json:"votes"`
+}
+
+// LoadVotes reads the vote history from ~/.config/valvefm/votes.json.
+func LoadVotes() (*Votes, error) {
+	path, err := votesPath()
+	if err != nil {
+		return nil, err
+	}
+	return loadVotesFrom(path)
+}
+
+func loadVotesFrom(path string) (*Votes, error) {
+	votes := &Votes{
+		path:  path,
+		items: map[string]time.Time{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return votes, nil
+		}
+		return nil, err
+	}
+
+	var stored votesFile
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	for uuid, at := range stored.Votes {
+		if uuid != "" {
+			votes.items[uuid] = at
+		}
+	}
+	return votes, nil
+}
+
+// CanVote reports whether uuid has not been voted for within VoteInterval.
+func (v *Votes) CanVote(uuid string, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	last, ok := v.items[uuid]
+	return !ok || now.Sub(last) >= VoteInterval
+}
+
+// NextVote returns when uuid may be voted for again; zero if it may be now.
+func (v *Votes) NextVote(uuid string, now time.Time) time.Time {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	last, ok := v.items[uuid]
+	if !ok || now.Sub(last) >= VoteInterval {
+		return time.Time{}
+	}
+	return last.Add(VoteInterval)
+}
+
+// Record stores a vote for uuid at now and drops expired entries.
+func (v *Votes) Record(uuid string, now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if uuid == "" {
+		return errors.New("station uuid is required")
+	}
+	v.items[uuid] = now
+	for id, at := range v.items {
+		if now.Sub(at) >= VoteInterval {
+			delete(v.items, id)
+		}
+	}
+	return v.saveLocked()
+}
+
+func (v *Votes) saveLocked() error {
+	if err := os.MkdirAll(filepath.Dir(v.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(votesFile{Votes: v.items}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(v.path, data, 0o644)
+}
+
+func votesPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "valvefm", "votes.json"), nil
+}
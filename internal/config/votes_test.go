@@ -0,0 +1,68 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestVotes(t *testing.T) *Votes {
+	t.Helper()
+	return &Votes{
+		path:  filepath.Join(t.TempDir(), "votes.json"),
+		items: make(map[string]time.Time),
+	}
+}
+
+func TestVotes_RateLimit(t *testing.T) {
+	votes := newTestVotes(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if !votes.CanVote("a", now) {
+		t.Fatal("CanVote() should allow a first vote")
+	}
+	if err := votes.Record("a", now); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if votes.CanVote("a", now.Add(time.Hour)) {
+		t.Error("CanVote() should refuse a second vote within the interval")
+	}
+	if got, want := votes.NextVote("a", now.Add(time.Hour)), now.Add(VoteInterval); !got.Equal(want) {
+		t.Errorf("NextVote() = %v, want %v", got, want)
+	}
+	if !votes.CanVote("b", now) {
+		t.Error("CanVote() should be per station")
+	}
+	if !votes.CanVote("a", now.Add(VoteInterval)) {
+		t.Error("CanVote() should allow voting again after the interval")
+	}
+}
+
+func TestVotes_Persistence(t *testing.T) {
+	votes := newTestVotes(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := votes.Record("old", now.Add(-VoteInterval)); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := votes.Record("a", now); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	loaded, err := loadVotesFrom(votes.path)
+	if err != nil {
+		t.Fatalf("loadVotesFrom() error = %v", err)
+	}
+	if loaded.CanVote("a", now) {
+		t.Error("loaded votes should keep the rate limit across restarts")
+	}
+	if _, ok := loaded.items["old"]; ok {
+		t.Error("expired votes should be pruned on save")
+	}
+}
+
+func TestVotes_RecordRequiresUUID(t *testing.T) {
+	votes := newTestVotes(t)
+	if err := votes.Record("", time.Now()); err == nil {
+		t.Error("Record() should reject an empty uuid")
+	}
+}
@@ -3,6 +3,7 @@ package player
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -14,16 +15,34 @@ type Backend interface {
 	LastURL() string
 }
 
+// NamedBackend is implemented by backends that can report which engine produces audio.
+type NamedBackend interface {
+	Name() string
+}
+
+// CodecPlayer is implemented by backends that pick an engine based on the stream codec.
+type CodecPlayer interface {
+	PlayCodec(url string, codec string) error
+}
+
 // CompositeBackend wraps multiple backends and selects the best one dynamically.
 type CompositeBackend struct {
-	mu      sync.Mutex
-	gp      *GoPlayer
-	ext     *Player
-	active  Backend
-	lastURL string
+	mu         sync.Mutex
+	order      []string
+	codecs     map[string][]string
+	available  map[string]Backend
+	active     Backend
+	activeName string
+	lastURL    string
 }
 
+// Play tries each backend in priority order until one accepts the stream.
 func (c *CompositeBackend) Play(url string) error {
+	return c.PlayCodec(url, "")
+}
+
+// PlayCodec is like Play but uses the per-codec priority override when one is configured.
+func (c *CompositeBackend) PlayCodec(url string, codec string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.lastURL = url
@@ -31,37 +50,37 @@ func (c *CompositeBackend) Play(url string) error {
 	// Stop any currently playing backend
 	if c.active != nil {
 		c.active.Stop()
+		c.active = nil
+		c.activeName = ""
 	}
 
-	var errGo error
-	// 1. Try pure Go backend
-	if c.gp != nil {
-		if err := c.gp.Play(url); err == nil {
-			c.active = c.gp
-			return nil
-		} else {
-			errGo = err
-		}
+	order := c.order
+	if override, ok := c.codecs[normalizeName(codec)]; ok {
+		order = override
 	}
 
-	// 2. Fallback to external player (if available)
-	// Useful for AAC/OGG streams that go-mp3 cannot decode
-	if c.ext != nil {
-		// If we're falling back from a Go error, we might want to ensure
-		// the previous backend is fully stopped/cleaned up, which Stop() does.
-		if err := c.ext.Play(url); err == nil {
-			c.active = c.ext
-			return nil
-		} else {
-			if errGo != nil {
-				return fmt.Errorf("go-audio: %v, external: %v", errGo, err)
-			}
-			return err
+	var failures []string
+	for _, name := range order {
+		backend, ok := c.available[name]
+		if !ok {
+			continue
 		}
+		// Falls through to the next backend on error, which is how AAC/OGG
+		// streams that go-mp3 cannot decode end up on an external player.
+		if err := backend.Play(url); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		c.active = backend
+		c.activeName = name
+		return nil
 	}
 
-	if errGo != nil {
-		return fmt.Errorf("format not supported in pure Go; please install mpv or ffplay to listen (error: %v)", errGo)
+	if len(failures) == 1 && strings.HasPrefix(failures[0], "go: ") {
+		return fmt.Errorf("format not supported in pure Go; please install mpv or ffplay to listen (error: %s)", strings.TrimPrefix(failures[0], "go: "))
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, ", "))
 	}
 	return errors.New("no audio backend available; please install mpv or ffplay")
 }
@@ -87,18 +106,39 @@ func (c *CompositeBackend) LastURL() string {
 	return c.lastURL
 }
 
-// New returns a smart player that tries pure Go audio first,
-// but falls back to system mpv/ffplay for unsupported formats (like AAC).
-func New() (Backend, error) {
-	gp := probeGoAudio()
-	ext, _ := newExternal() // optional fallback
+// Name returns the registry name of the backend that last started playback.
+func (c *CompositeBackend) Name() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeName
+}
+
+// Title returns the stream title reported by the active backend, if it
+// reads titles.
+func (c *CompositeBackend) Title() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if titled, ok := c.active.(TitledBackend); ok {
+		return titled.Title()
+	}
+	return ""
+}
 
-	if gp == nil && ext == nil {
-		return nil, errors.New("no player backend available")
+// Backends returns the names of the usable backends in default priority order.
+func (c *CompositeBackend) Backends() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.order))
+	for _, name := range c.order {
+		if _, ok := c.available[name]; ok {
+			names = append(names, name)
+		}
 	}
+	return names
+}
 
-	return &CompositeBackend{
-		gp:  gp,
-		ext: ext,
-	}, nil
+// New returns a smart player that tries pure Go audio first,
+// but falls back to system mpv/ffplay/vlc/gstreamer for unsupported formats (like AAC).
+func New() (Backend, error) {
+	return NewWithOptions(Options{})
 }
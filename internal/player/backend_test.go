@@ -80,7 +80,8 @@ func TestCompositeBackend_LastURL_Empty(t *testing.T) {
 func TestCompositeBackend_LastURL_AfterPlay(t *testing.T) {
 	mock := &mockBackend{}
 	cb := &CompositeBackend{
-		gp: &GoPlayer{}, // Will fail, but that's ok for this test
+		order:     []string{"go"},
+		available: map[string]Backend{"go": &GoPlayer{}}, // Will fail, but that's ok for this test
 	}
 	// We can't easily test with real backends, but we can test lastURL is set
 	url := "http://example.com/stream"
@@ -98,8 +99,8 @@ func TestCompositeBackend_LastURL_AfterPlay(t *testing.T) {
 
 func TestCompositeBackend_Play_NoBackends(t *testing.T) {
 	cb := &CompositeBackend{
-		gp:  nil,
-		ext: nil,
+		order:     []string{"go", "mpv"},
+		available: nil,
 	}
 
 	err := cb.Play("http://example.com/stream")
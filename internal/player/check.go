@@ -0,0 +1,218 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCheckWorkers = 6
+	defaultCheckTimeout = 8 * time.Second
+	defaultCheckSlow    = 3 * time.Second
+	defaultCheckTTL     = 10 * time.Minute
+
+	// checkBytes is how much of a stream must arrive for it to count as live.
+	checkBytes = 1024
+)
+
+// StreamStatus is the outcome of a stream check.
+type StreamStatus int
+
+const (
+	StreamOK StreamStatus = iota
+	StreamSlow
+	StreamDead
+)
+
+// CheckResult describes one stream check.
+type CheckResult struct {
+	Status StreamStatus
+	// Reason explains a slow or dead result, e.g. "HTTP 404" or "timeout".
+	Reason string
+	// Latency is the time until the first bytes of audio arrived.
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+// CheckerOptions configures a Checker. Zero values use the defaults.
+type CheckerOptions struct {
+	// Workers bounds how many streams are checked at once.
+	Workers int
+	// Timeout gives up on a stream that has not sent audio by then.
+	Timeout time.Duration
+	// SlowAfter marks streams that took longer than this to start.
+	SlowAfter time.Duration
+	// TTL is how long a result is reused before the stream is checked again.
+	TTL time.Duration
+}
+
+// Checker tests whether stream URLs answer with audio, independently of the
+// directory's own (often stale) last check. Results are cached per URL.
+type Checker struct {
+	opts   CheckerOptions
+	client *http.Client
+	now    func() time.Time
+
+	mu      sync.Mutex
+	results map[string]CheckResult
+}
+
+// NewChecker returns a Checker with opts applied over the defaults.
+func NewChecker(opts CheckerOptions) *Checker {
+	if opts.Workers <= 0 {
+		opts.Workers = defaultCheckWorkers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultCheckTimeout
+	}
+	if opts.SlowAfter <= 0 {
+		opts.SlowAfter = defaultCheckSlow
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultCheckTTL
+	}
+	return &Checker{
+		opts:    opts,
+		client:  http.DefaultClient,
+		now:     time.Now,
+		results: make(map[string]CheckResult),
+	}
+}
+
+// Cached returns the result for url if it was checked within the TTL.
+func (c *Checker) Cached(url string) (CheckResult, bool) {
+	if c == nil || url == "" {
+		return CheckResult{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[url]
+	if !ok || c.now().Sub(result.CheckedAt) >= c.opts.TTL {
+		return CheckResult{}, false
+	}
+	return result, true
+}
+
+// CheckAll checks urls with a bounded pool of workers, calling report as
+// each result arrives. URLs with a fresh cached result are skipped. It
+// returns once every check finished or ctx is done; cancelled checks are
+// neither reported nor cached.
+func (c *Checker) CheckAll(ctx context.Context, urls []string, report func(url string, result CheckResult)) {
+	seen := make(map[string]bool, len(urls))
+	jobs := make(chan string)
+	var (
+		wg       sync.WaitGroup
+		reportMu sync.Mutex
+	)
+	for range min(c.opts.Workers, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range jobs {
+				result, ok := c.Check(ctx, url)
+				if !ok || report == nil {
+					continue
+				}
+				reportMu.Lock()
+				report(url, result)
+				reportMu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, url := range urls {
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		if _, ok := c.Cached(url); ok {
+			continue
+		}
+		select {
+		case jobs <- url:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// Check opens url and waits for the first bytes of audio. The boolean is
+// false when ctx was cancelled, in which case nothing is cached.
+func (c *Checker) Check(ctx context.Context, url string) (CheckResult, bool) {
+	start := c.now()
+	result := c.check(ctx, url)
+	if ctx.Err() != nil {
+		return CheckResult{}, false
+	}
+	if result.Status == StreamOK && result.Latency > c.opts.SlowAfter {
+		result.Status = StreamSlow
+		result.Reason = fmt.Sprintf("slow %.1fs", result.Latency.Seconds())
+	}
+	result.CheckedAt = start
+
+	c.mu.Lock()
+	c.results[url] = result
+	c.mu.Unlock()
+	return result, true
+}
+
+func (c *Checker) check(ctx context.Context, url string) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	// HEAD is unreliable on Icecast and Shoutcast, so read the first bytes
+	// of a GET instead.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return deadResult("bad url")
+	}
+	req.Header.Set("User-Agent", "ValveFM/1.0")
+	req.Header.Set("Icy-MetaData", "0")
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return deadResult(checkErrorReason(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return deadResult(fmt.Sprintf("HTTP %d", resp.StatusCode))
+	}
+	contentType := strings.ToLower(strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0]))
+	if strings.HasPrefix(contentType, "text/html") {
+		return deadResult("web page")
+	}
+
+	n, err := io.ReadFull(resp.Body, make([]byte, checkBytes))
+	if n == 0 {
+		if err == nil || errors.Is(err, io.EOF) {
+			return deadResult("empty")
+		}
+		return deadResult(checkErrorReason(err))
+	}
+	return CheckResult{Status: StreamOK, Latency: time.Since(start)}
+}
+
+func deadResult(reason string) CheckResult {
+	return CheckResult{Status: StreamDead, Reason: reason}
+}
+
+func checkErrorReason(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	var netErr interface{ Timeout() bool }
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	return "unreachable"
+}
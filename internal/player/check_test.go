@@ -0,0 +1,137 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newCheckServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	audio := strings.Repeat("\xff", checkBytes)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/live":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte(audio))
+		case "/slow":
+			time.Sleep(60 * time.Millisecond)
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte(audio))
+		case "/hang":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestChecker_CheckAll(t *testing.T) {
+	var hits atomic.Int32
+	server := newCheckServer(t, &hits)
+	checker := NewChecker(CheckerOptions{Workers: 2, Timeout: 200 * time.Millisecond, SlowAfter: 40 * time.Millisecond})
+
+	urls := []string{
+		server.URL + "/live",
+		server.URL + "/slow",
+		server.URL + "/hang",
+		server.URL + "/page",
+		server.URL + "/missing",
+		server.URL + "/live",
+	}
+	var (
+		mu      sync.Mutex
+		results = map[string]CheckResult{}
+	)
+	checker.CheckAll(context.Background(), urls, func(url string, result CheckResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		results[url] = result
+	})
+
+	want := map[string]struct {
+		status StreamStatus
+		reason string
+	}{
+		"/live":    {StreamOK, ""},
+		"/slow":    {StreamSlow, "slow"},
+		"/hang":    {StreamDead, "timeout"},
+		"/page":    {StreamDead, "web page"},
+		"/missing": {StreamDead, "HTTP 404"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("reported %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for path, w := range want {
+		got := results[server.URL+path]
+		if got.Status != w.status || !strings.HasPrefix(got.Reason, w.reason) {
+			t.Errorf("%s = %+v, want status %d reason %q", path, got, w.status, w.reason)
+		}
+		if cached, ok := checker.Cached(server.URL + path); !ok || cached.Status != w.status {
+			t.Errorf("Cached(%s) = %+v, %v", path, cached, ok)
+		}
+	}
+	if got := hits.Load(); got != 5 {
+		t.Errorf("server hits = %d, duplicate urls should be checked once", got)
+	}
+
+	checker.CheckAll(context.Background(), urls, nil)
+	if got := hits.Load(); got != 5 {
+		t.Errorf("server hits = %d, cached results should not be checked again", got)
+	}
+}
+
+func TestChecker_ResultsExpire(t *testing.T) {
+	var hits atomic.Int32
+	server := newCheckServer(t, &hits)
+	checker := NewChecker(CheckerOptions{TTL: time.Minute})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	checker.now = func() time.Time { return now }
+
+	if _, ok := checker.Check(context.Background(), server.URL+"/live"); !ok {
+		t.Fatal("Check() should report a result")
+	}
+	if _, ok := checker.Cached(server.URL + "/live"); !ok {
+		t.Error("result should be cached within the TTL")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := checker.Cached(server.URL + "/live"); ok {
+		t.Error("result should expire after the TTL")
+	}
+}
+
+func TestChecker_CancelledChecksAreNotCached(t *testing.T) {
+	var hits atomic.Int32
+	server := newCheckServer(t, &hits)
+	checker := NewChecker(CheckerOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	reported := false
+	checker.CheckAll(ctx, []string{server.URL + "/hang"}, func(string, CheckResult) { reported = true })
+	if reported {
+		t.Error("a cancelled check should not be reported")
+	}
+	if _, ok := checker.Cached(server.URL + "/hang"); ok {
+		t.Error("a cancelled check should not be cached")
+	}
+}
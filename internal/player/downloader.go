@@ -3,27 +3,61 @@ package player
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const ffplayURL = "https://www.gyan.dev/ffmpeg/builds/ffmpeg-release-essentials.zip"
 
+// DownloadOptions configures DownloadFFplayWithOptions.
+type DownloadOptions struct {
+	// URL of the FFmpeg zip archive. Defaults to the gyan.dev essentials build.
+	URL string
+	// SHA256 is a pinned hex digest of the archive. When empty the digest is
+	// fetched from ChecksumURL.
+	SHA256 string
+	// ChecksumURL serves the archive digest. Defaults to URL + ".sha256".
+	ChecksumURL string
+	// Dir receives ffplay.exe and its DLLs. Defaults to ~/.config/valvefm/bin.
+	Dir string
+	// Progress, if set, is called as bytes arrive. total is -1 when unknown.
+	Progress func(done int64, total int64)
+}
+
 // DownloadFFplay downloads and extracts ffplay.exe and required DLLs on Windows.
-func DownloadFFplay(ctx context.Context) (string, error) {
+func DownloadFFplay(ctx context.Context, progress func(done int64, total int64)) (string, error) {
 	if runtime.GOOS != "windows" {
 		return "", errors.New("ffplay auto-download is supported only on Windows")
 	}
+	return DownloadFFplayWithOptions(ctx, DownloadOptions{Progress: progress})
+}
 
-	dir, err := downloadDir()
-	if err != nil {
-		return "", err
+// DownloadFFplayWithOptions downloads the FFmpeg archive, resuming a previous
+// partial download if one exists, verifies its SHA-256 digest and extracts ffplay.
+func DownloadFFplayWithOptions(ctx context.Context, opts DownloadOptions) (string, error) {
+	if opts.URL == "" {
+		opts.URL = ffplayURL
+	}
+	if opts.ChecksumURL == "" {
+		opts.ChecksumURL = opts.URL + ".sha256"
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		var err error
+		if dir, err = downloadDir(); err != nil {
+			return "", err
+		}
 	}
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return "", err
@@ -35,46 +69,176 @@ func DownloadFFplay(ctx context.Context) (string, error) {
 	}
 
 	client := &http.Client{Timeout: 10 * time.Minute}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ffplayURL, nil)
+
+	want := strings.ToLower(strings.TrimSpace(opts.SHA256))
+	if want == "" {
+		var err error
+		if want, err = fetchChecksum(ctx, client, opts.ChecksumURL); err != nil {
+			return "", fmt.Errorf("ffplay checksum: %w", err)
+		}
+	}
+
+	zipPath := filepath.Join(dir, "ffplay-download.zip.part")
+	if err := downloadResumable(ctx, client, opts.URL, zipPath, opts.Progress); err != nil {
+		return "", err
+	}
+
+	got, err := fileSHA256(zipPath)
 	if err != nil {
 		return "", err
 	}
+	if got != want {
+		// A corrupt partial file would fail forever, so start over next time.
+		_ = os.Remove(zipPath)
+		return "", fmt.Errorf("ffplay checksum mismatch: got %s, want %s", got, want)
+	}
+
+	if err := extractFFplay(zipPath, dir); err != nil {
+		_ = os.Remove(zipPath)
+		return "", err
+	}
+	_ = os.Remove(zipPath)
+
+	if !isExecutable(ffplayPath) {
+		return "", errors.New("ffplay download did not produce ffplay.exe")
+	}
+	return ffplayPath, nil
+}
+
+// downloadResumable fetches url into path, continuing from the existing file
+// size with an HTTP Range request when possible.
+func downloadResumable(ctx context.Context, client *http.Client, url string, path string, progress func(int64, int64)) error {
+	var offset int64
+	if info, err := os.Stat(path); err == nil {
+		offset = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", errors.New("failed to download ffplay")
+	flags := os.O_CREATE | os.O_WRONLY
+	total := int64(-1)
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags |= os.O_APPEND
+		total = contentRangeTotal(resp.Header.Get("Content-Range"))
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// The partial file is already complete; verification decides if it is usable.
+		if progress != nil {
+			progress(offset, offset)
+		}
+		return nil
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		flags |= os.O_TRUNC
+		offset = 0
+		if resp.ContentLength >= 0 {
+			total = resp.ContentLength
+		}
+	default:
+		return fmt.Errorf("failed to download ffplay: %s", resp.Status)
+	}
+
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader = resp.Body
+	if progress != nil {
+		progress(offset, total)
+		body = &progressReader{r: resp.Body, done: offset, total: total, report: progress}
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		// Keep what we have so the next attempt can resume.
+		_ = file.Close()
+		return err
 	}
+	return file.Close()
+}
 
-	zipFile, err := os.CreateTemp(dir, "ffplay-*.zip")
+func fetchChecksum(ctx context.Context, client *http.Client, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return "", err
 	}
-	zipPath := zipFile.Name()
-	if _, err := io.Copy(zipFile, resp.Body); err != nil {
-		_ = zipFile.Close()
-		_ = os.Remove(zipPath)
+	resp, err := client.Do(req)
+	if err != nil {
 		return "", err
 	}
-	if err := zipFile.Close(); err != nil {
-		_ = os.Remove(zipPath)
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("request failed: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
 		return "", err
 	}
 
-	if err := extractFFplay(zipPath, dir); err != nil {
-		_ = os.Remove(zipPath)
+	// Accept both a bare digest and the sha256sum "<digest>  <file>" format.
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", errors.New("empty checksum")
+	}
+	sum := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid checksum %q", fields[0])
+	}
+	return sum, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
 		return "", err
 	}
-	_ = os.Remove(zipPath)
+	defer file.Close()
 
-	if !isExecutable(ffplayPath) {
-		return "", errors.New("ffplay download did not produce ffplay.exe")
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
 	}
-	return ffplayPath, nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// contentRangeTotal parses the complete length from "bytes 100-199/200".
+func contentRangeTotal(header string) int64 {
+	slash := strings.LastIndex(header, "/")
+	if slash < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(header[slash+1:]), 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+type progressReader struct {
+	r      io.Reader
+	done   int64
+	total  int64
+	report func(int64, int64)
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	if n > 0 {
+		p.done += int64(n)
+		p.report(p.done, p.total)
+	}
+	return n, err
 }
 
 func extractFFplay(zipPath string, destDir string) error {
@@ -87,6 +251,9 @@ func extractFFplay(zipPath string, destDir string) error {
 	extracted := false
 	for _, file := range zipReader.File {
 		name := strings.ReplaceAll(file.Name, "\\", "/")
+		if err := checkZipEntry(name); err != nil {
+			return err
+		}
 		if !strings.Contains(name, "/bin/") {
 			continue
 		}
@@ -95,7 +262,11 @@ func extractFFplay(zipPath string, destDir string) error {
 		if lower != "ffplay.exe" && !strings.HasSuffix(lower, ".dll") {
 			continue
 		}
-		if err := extractZipFile(file, filepath.Join(destDir, base)); err != nil {
+		destPath := filepath.Join(destDir, base)
+		if !withinDir(destDir, destPath) {
+			return fmt.Errorf("archive entry %q escapes destination", file.Name)
+		}
+		if err := extractZipFile(file, destPath); err != nil {
 			return err
 		}
 		if lower == "ffplay.exe" {
@@ -109,6 +280,27 @@ func extractFFplay(zipPath string, destDir string) error {
 	return nil
 }
 
+// checkZipEntry rejects absolute paths and ".." components (zip-slip).
+func checkZipEntry(name string) error {
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) || (len(name) > 1 && name[1] == ':') {
+		return fmt.Errorf("archive entry %q has an absolute path", name)
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return fmt.Errorf("archive entry %q escapes destination", name)
+		}
+	}
+	return nil
+}
+
+func withinDir(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
 func extractZipFile(file *zip.File, destPath string) error {
 	if file.FileInfo().IsDir() {
 		return nil
@@ -137,26 +329,10 @@ func downloadDir() (string, error) {
 	return filepath.Join(configDir, "valvefm", "bin"), nil
 }
 
-func findDownloadedPlayer() (string, string) {
+func findDownloadedPlayer(backend string) string {
 	dir, err := downloadDir()
 	if err != nil {
-		return "", ""
-	}
-
-	candidates := []struct {
-		backend string
-		name    string
-	}{
-		{backend: "ffplay", name: "ffplay.exe"},
-		{backend: "ffplay", name: "ffplay"},
-		{backend: "mpv", name: "mpv.exe"},
-		{backend: "mpv", name: "mpv"},
-	}
-	for _, candidate := range candidates {
-		path := filepath.Join(dir, candidate.name)
-		if isExecutable(path) {
-			return path, candidate.backend
-		}
+		return ""
 	}
-	return "", ""
+	return findPlayerIn(dir, backend)
 }
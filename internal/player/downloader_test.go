@@ -0,0 +1,224 @@
+package player
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testArchive builds an FFmpeg-like zip containing the given entries.
+func testArchive(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("Create(%q) error = %v", name, err)
+		}
+		w.Write([]byte(content))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type archiveServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	ranges []string
+}
+
+func newArchiveServer(t *testing.T, archive []byte, checksum string) *archiveServer {
+	t.Helper()
+	s := &archiveServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			w.Write([]byte(checksum + "  ffmpeg.zip\n"))
+			return
+		}
+		s.mu.Lock()
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		s.mu.Unlock()
+		http.ServeContent(w, r, "ffmpeg.zip", time.Time{}, bytes.NewReader(archive))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *archiveServer) rangeHeaders() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.ranges...)
+}
+
+func TestDownloadFFplayWithOptions_FetchedChecksum(t *testing.T) {
+	archive := testArchive(t, map[string]string{
+		"ffmpeg-7.0/bin/ffplay.exe":    "ffplay",
+		"ffmpeg-7.0/bin/avcodec.dll":   "dll",
+		"ffmpeg-7.0/bin/ffmpeg.exe":    "skip",
+		"ffmpeg-7.0/doc/readme.txt":    "skip",
+		"ffmpeg-7.0/bin/swscale-7.dll": "dll",
+	})
+	server := newArchiveServer(t, archive, sha256Hex(archive))
+	dir := t.TempDir()
+
+	var lastDone, lastTotal int64
+	path, err := DownloadFFplayWithOptions(context.Background(), DownloadOptions{
+		URL: server.URL + "/ffmpeg.zip",
+		Dir: dir,
+		Progress: func(done, total int64) {
+			lastDone, lastTotal = done, total
+		},
+	})
+	if err != nil {
+		t.Fatalf("DownloadFFplayWithOptions() error = %v", err)
+	}
+	if path != filepath.Join(dir, "ffplay.exe") {
+		t.Errorf("path = %q", path)
+	}
+	for _, name := range []string{"ffplay.exe", "avcodec.dll", "swscale-7.dll"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not extracted: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ffmpeg.exe")); err == nil {
+		t.Error("ffmpeg.exe should not be extracted")
+	}
+	if lastTotal != int64(len(archive)) || lastDone != lastTotal {
+		t.Errorf("final progress = %d/%d, want %d/%d", lastDone, lastTotal, len(archive), len(archive))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ffplay-download.zip.part")); err == nil {
+		t.Error("partial download should be removed after extraction")
+	}
+}
+
+func TestDownloadFFplayWithOptions_Resume(t *testing.T) {
+	archive := testArchive(t, map[string]string{"ffmpeg/bin/ffplay.exe": strings.Repeat("x", 4096)})
+	server := newArchiveServer(t, archive, "")
+	dir := t.TempDir()
+
+	half := len(archive) / 2
+	if err := os.WriteFile(filepath.Join(dir, "ffplay-download.zip.part"), archive[:half], 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var firstDone int64 = -1
+	_, err := DownloadFFplayWithOptions(context.Background(), DownloadOptions{
+		URL:    server.URL + "/ffmpeg.zip",
+		SHA256: sha256Hex(archive),
+		Dir:    dir,
+		Progress: func(done, total int64) {
+			if firstDone < 0 {
+				firstDone = done
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("DownloadFFplayWithOptions() error = %v", err)
+	}
+
+	ranges := server.rangeHeaders()
+	if len(ranges) != 1 || ranges[0] != "bytes="+strconv.Itoa(half)+"-" {
+		t.Errorf("Range headers = %v, want [bytes=%d-]", ranges, half)
+	}
+	if firstDone != int64(half) {
+		t.Errorf("first progress = %d, want %d", firstDone, half)
+	}
+}
+
+func TestDownloadFFplayWithOptions_ChecksumMismatch(t *testing.T) {
+	archive := testArchive(t, map[string]string{"ffmpeg/bin/ffplay.exe": "ffplay"})
+	server := newArchiveServer(t, archive, strings.Repeat("0", 64))
+	dir := t.TempDir()
+
+	_, err := DownloadFFplayWithOptions(context.Background(), DownloadOptions{
+		URL: server.URL + "/ffmpeg.zip",
+		Dir: dir,
+	})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("error = %v, want checksum mismatch", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ffplay.exe")); err == nil {
+		t.Error("ffplay.exe should not be extracted from an unverified archive")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ffplay-download.zip.part")); err == nil {
+		t.Error("corrupt partial download should be removed")
+	}
+}
+
+func TestDownloadFFplayWithOptions_BadChecksumFile(t *testing.T) {
+	archive := testArchive(t, map[string]string{"ffmpeg/bin/ffplay.exe": "ffplay"})
+	server := newArchiveServer(t, archive, "not-a-digest")
+
+	_, err := DownloadFFplayWithOptions(context.Background(), DownloadOptions{
+		URL: server.URL + "/ffmpeg.zip",
+		Dir: t.TempDir(),
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid checksum file")
+	}
+}
+
+func TestExtractFFplay_RejectsZipSlip(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry string
+	}{
+		{"parent traversal", "ffmpeg/bin/../../../evil.dll"},
+		{"windows traversal", "ffmpeg\\bin\\..\\..\\evil.dll"},
+		{"absolute", "/ffmpeg/bin/ffplay.exe"},
+		{"drive letter", "C:/ffmpeg/bin/ffplay.exe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := testArchive(t, map[string]string{
+				"ffmpeg/bin/ffplay.exe": "ffplay",
+				tt.entry:                "evil",
+			})
+			tmp := t.TempDir()
+			zipPath := filepath.Join(tmp, "a.zip")
+			os.WriteFile(zipPath, archive, 0o644)
+			dest := filepath.Join(tmp, "dest")
+			os.Mkdir(dest, 0o755)
+
+			if err := extractFFplay(zipPath, dest); err == nil {
+				t.Errorf("extractFFplay() should reject entry %q", tt.entry)
+			}
+		})
+	}
+}
+
+func TestContentRangeTotal(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int64
+	}{
+		{"bytes 100-199/200", 200},
+		{"bytes 0-0/*", -1},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := contentRangeTotal(tt.header); got != tt.want {
+			t.Errorf("contentRangeTotal(%q) = %d, want %d", tt.header, got, tt.want)
+		}
+	}
+}
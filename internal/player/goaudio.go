@@ -19,6 +19,7 @@ type GoPlayer struct {
 	streamer    beep.StreamSeekCloser
 	ctrl        *beep.Ctrl
 	resp        *http.Response
+	icy         *icyReader
 	lastURL     string
 	playing     bool
 	initialized bool
@@ -68,7 +69,7 @@ func (g *GoPlayer) Play(url string) error {
 		return fmt.Errorf("request: %w", err)
 	}
 	req.Header.Set("User-Agent", "ValveFM/1.0")
-	req.Header.Set("Icy-MetaData", "0")
+	req.Header.Set("Icy-MetaData", "1")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -78,6 +79,7 @@ func (g *GoPlayer) Play(url string) error {
 		resp.Body.Close()
 		return fmt.Errorf("stream HTTP %d", resp.StatusCode)
 	}
+	icy := watchICY(resp)
 
 	// Decode MP3 via beep (wraps go-mp3)
 	streamer, format, err := mp3.Decode(resp.Body)
@@ -106,6 +108,7 @@ func (g *GoPlayer) Play(url string) error {
 	g.streamer = streamer
 	g.ctrl = ctrl
 	g.resp = resp
+	g.icy = icy
 	g.playing = true
 
 	return nil
@@ -138,6 +141,7 @@ func (g *GoPlayer) cleanupLocked() {
 	// We nil out resp to avoid double-close attempts; the GC will handle cleanup.
 	// Explicitly closing resp.Body here could cause issues if streamer already closed it.
 	g.resp = nil
+	g.icy = nil
 	g.playing = false
 }
 
@@ -153,8 +157,15 @@ func (g *GoPlayer) LastURL() string {
 	return g.lastURL
 }
 
-func probeGoAudio() *GoPlayer {
-	return NewGoPlayer()
+func (g *GoPlayer) Name() string {
+	return "go"
+}
+
+// Title returns the stream title read from the playing stream.
+func (g *GoPlayer) Title() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.icy.Title()
 }
 
 var _ Backend = (*GoPlayer)(nil)
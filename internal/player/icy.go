@@ -0,0 +1,122 @@
+package player
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TitledBackend is implemented by backends that read stream titles (ICY
+// metadata) from the stream they are playing.
+type TitledBackend interface {
+	// Title returns the latest stream title, or "" when none was sent.
+	Title() string
+}
+
+// maxMetaInt bounds the announced metadata interval; real servers use a
+// few kilobytes.
+const maxMetaInt = 1 << 20
+
+// icyReader strips the ICY metadata blocks a server interleaves with the
+// audio and remembers the latest StreamTitle.
+type icyReader struct {
+	body    io.ReadCloser
+	r       *bufio.Reader
+	metaInt int
+	left    int
+
+	mu    sync.Mutex
+	title string
+}
+
+// watchICY replaces resp.Body with an icyReader when the server sends
+// metadata, which requests must ask for with "Icy-MetaData: 1". It returns
+// nil, leaving the body untouched, when the stream carries none.
+func watchICY(resp *http.Response) *icyReader {
+	metaInt, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("icy-metaint")))
+	if err != nil || metaInt <= 0 || metaInt > maxMetaInt {
+		return nil
+	}
+	icy := &icyReader{body: resp.Body, r: bufio.NewReader(resp.Body), metaInt: metaInt, left: metaInt}
+	resp.Body = icy
+	return icy
+}
+
+func (i *icyReader) Read(p []byte) (int, error) {
+	for i.left == 0 {
+		if err := i.readMeta(); err != nil {
+			return 0, err
+		}
+	}
+	if len(p) > i.left {
+		p = p[:i.left]
+	}
+	n, err := i.r.Read(p)
+	i.left -= n
+	return n, err
+}
+
+func (i *icyReader) readMeta() error {
+	size, err := i.r.ReadByte()
+	if err != nil {
+		return err
+	}
+	i.left = i.metaInt
+	if size == 0 {
+		return nil
+	}
+	block := make([]byte, int(size)*16)
+	if _, err := io.ReadFull(i.r, block); err != nil {
+		return err
+	}
+	if title := streamTitle(string(block)); title != "" {
+		i.mu.Lock()
+		i.title = title
+		i.mu.Unlock()
+	}
+	return nil
+}
+
+func (i *icyReader) Close() error {
+	return i.body.Close()
+}
+
+// Title returns the latest stream title; it is safe to call while the
+// stream is being read.
+func (i *icyReader) Title() string {
+	if i == nil {
+		return ""
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.title
+}
+
+// streamTitle extracts StreamTitle='...'; from an ICY metadata block.
+func streamTitle(block string) string {
+	block = strings.TrimRight(block, "\x00")
+	_, rest, ok := strings.Cut(block, "StreamTitle='")
+	if !ok {
+		return ""
+	}
+	// Titles may contain quotes, so the value ends at the last "';".
+	if end := strings.Index(rest, "';"); end >= 0 {
+		if last := strings.LastIndex(rest, "';"); last > end && !strings.Contains(rest[end:last], "='") {
+			end = last
+		}
+		rest = rest[:end]
+	} else {
+		rest = strings.TrimSuffix(rest, "'")
+	}
+	return strings.TrimSpace(rest)
+}
+
+var (
+	_ TitledBackend = (*CompositeBackend)(nil)
+	_ TitledBackend = (*GoPlayer)(nil)
+	_ TitledBackend = (*Player)(nil)
+	_ TitledBackend = (*SinkPlayer)(nil)
+)
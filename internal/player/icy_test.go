@@ -0,0 +1,122 @@
+package player
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// icyBlock pads metadata to whole 16-byte units behind its length byte.
+func icyBlock(meta string) []byte {
+	size := (len(meta) + 15) / 16
+	block := make([]byte, 1+size*16)
+	block[0] = byte(size)
+	copy(block[1:], meta)
+	return block
+}
+
+func TestWatchICY(t *testing.T) {
+	const metaInt = 32
+	audio := bytes.Repeat([]byte{0xff}, metaInt)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		if r.URL.Path == "/plain" {
+			w.Write(audio)
+			return
+		}
+		w.Header().Set("icy-metaint", "32")
+		for _, meta := range []string{
+			"StreamTitle='Artist - First';StreamUrl='';",
+			"",
+			"StreamTitle='It's Second';",
+			"StreamTitle='';",
+		} {
+			w.Write(audio)
+			if meta == "" {
+				w.Write([]byte{0})
+				continue
+			}
+			w.Write(icyBlock(meta))
+		}
+		w.Write(audio[:10])
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/live")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+	icy := watchICY(resp)
+	if icy == nil {
+		t.Fatal("watchICY() should wrap a stream with icy-metaint")
+	}
+
+	// Reading in small pieces must still strip every block.
+	var got []byte
+	var titles []string
+	buf := make([]byte, 7)
+	for {
+		n, err := resp.Body.Read(buf)
+		got = append(got, buf[:n]...)
+		if title := icy.Title(); title != "" && (len(titles) == 0 || titles[len(titles)-1] != title) {
+			titles = append(titles, title)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+	}
+	if want := 4*metaInt + 10; len(got) != want || bytes.IndexByte(got, 0xff) != 0 || bytes.Count(got, []byte{0xff}) != want {
+		t.Errorf("read %d audio bytes, want %d without metadata", len(got), want)
+	}
+	if want := []string{"Artist - First", "It's Second"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+
+	plain, err := http.Get(server.URL + "/plain")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer plain.Body.Close()
+	if icy := watchICY(plain); icy != nil || icy.Title() != "" {
+		t.Error("watchICY() should leave streams without metadata alone")
+	}
+}
+
+func TestTitleWriter(t *testing.T) {
+	tests := map[string]string{
+		"mpv":    "Playing: http://x/\n File tags:\n  icy-title: Band - Song\nA: 00:00:01\r",
+		"ffplay": "  Metadata:\n    icy-name        : Jazz FM\n    StreamTitle     : Band - Song\n",
+	}
+	for backend, output := range tests {
+		var titles []string
+		w := &titleWriter{parse: titleParsers[backend], set: func(title string) { titles = append(titles, title) }}
+		// Output arrives in arbitrary chunks.
+		for _, chunk := range []string{output[:9], output[9:20], output[20:]} {
+			w.Write([]byte(chunk))
+		}
+		if !reflect.DeepEqual(titles, []string{"Band - Song"}) {
+			t.Errorf("%s titles = %q", backend, titles)
+		}
+	}
+}
+
+func TestStreamTitle(t *testing.T) {
+	tests := map[string]string{
+		"StreamTitle='Band - Song';StreamUrl='http://x/';": "Band - Song",
+		"StreamTitle='Rock 'n' Roll';\x00\x00":             "Rock 'n' Roll",
+		"StreamTitle='Unterminated":                        "Unterminated",
+		"StreamUrl='http://x/';":                           "",
+	}
+	for block, want := range tests {
+		if got := streamTitle(block); got != want {
+			t.Errorf("streamTitle(%q) = %q, want %q", block, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package player
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -16,22 +17,38 @@ type Player struct {
 	backend string
 	path    string
 	lastURL string
+	title   string
 }
 
-func newExternal() (*Player, error) {
-	if path, backend := findBundledPlayer(); path != "" {
-		return &Player{backend: backend, path: path}, nil
+// executableNames lists the binaries that provide each external backend.
+var executableNames = map[string][]string{
+	"mpv":       {"mpv"},
+	"ffplay":    {"ffplay"},
+	"vlc":       {"cvlc", "vlc"},
+	"gstreamer": {"gst-launch-1.0"},
+}
+
+func externalFactory(backend string) Factory {
+	return func(Options) (Backend, error) {
+		return newExternalBackend(backend)
 	}
-	if path, backend := findDownloadedPlayer(); path != "" {
+}
+
+// newExternalBackend locates the executable for backend, preferring a bundled
+// copy next to the binary, then a downloaded one, then PATH.
+func newExternalBackend(backend string) (*Player, error) {
+	if path := findBundledPlayer(backend); path != "" {
 		return &Player{backend: backend, path: path}, nil
 	}
-	if path, err := exec.LookPath("mpv"); err == nil {
-		return &Player{backend: "mpv", path: path}, nil
+	if path := findDownloadedPlayer(backend); path != "" {
+		return &Player{backend: backend, path: path}, nil
 	}
-	if path, err := exec.LookPath("ffplay"); err == nil {
-		return &Player{backend: "ffplay", path: path}, nil
+	for _, name := range executableNames[backend] {
+		if path, err := exec.LookPath(name); err == nil {
+			return &Player{backend: backend, path: path}, nil
+		}
 	}
-	return nil, errors.New("mpv or ffplay not found (bundle one or add to PATH)")
+	return nil, fmt.Errorf("%s not found (bundle it or add to PATH)", backend)
 }
 
 func (p *Player) Play(url string) error {
@@ -44,19 +61,40 @@ func (p *Player) Play(url string) error {
 
 	_ = p.stopLocked()
 	p.lastURL = url
+	p.title = ""
 
 	var cmd *exec.Cmd
 	switch p.backend {
 	case "mpv":
 		cmd = exec.Command(p.path, "--no-video", "--quiet", url)
 	case "ffplay":
-		cmd = exec.Command(p.path, "-nodisp", "-autoexit", "-loglevel", "quiet", url)
+		// Info level prints the stream metadata, -nostats drops the status line.
+		cmd = exec.Command(p.path, "-nodisp", "-autoexit", "-loglevel", "info", "-nostats", url)
+	case "vlc":
+		cmd = exec.Command(p.path, "--intf", "dummy", "--no-video", "--play-and-exit", "--quiet", url)
+	case "gstreamer":
+		cmd = exec.Command(p.path, "-q", "playbin", "uri="+url, "video-sink=fakesink")
 	default:
 		return errors.New("no audio backend available")
 	}
 
 	cmd.Stdout = io.Discard
 	cmd.Stderr = io.Discard
+	if parse := titleParsers[p.backend]; parse != nil {
+		titles := &titleWriter{parse: parse, set: func(title string) {
+			p.mu.Lock()
+			if p.cmd == cmd {
+				p.title = title
+			}
+			p.mu.Unlock()
+		}}
+		// mpv prints tags on stdout, ffplay logs them on stderr.
+		if p.backend == "ffplay" {
+			cmd.Stderr = titles
+		} else {
+			cmd.Stdout = titles
+		}
+	}
 	if err := cmd.Start(); err != nil {
 		return err
 	}
@@ -103,31 +141,84 @@ func (p *Player) LastURL() string {
 	return p.lastURL
 }
 
-func findBundledPlayer() (string, string) {
-	exe, err := os.Executable()
-	if err != nil {
-		return "", ""
-	}
-	dir := filepath.Dir(exe)
+func (p *Player) Name() string {
+	return p.backend
+}
 
-	candidates := []struct {
-		backend string
-		name    string
-	}{
-		{backend: "mpv", name: "mpv"},
-		{backend: "mpv", name: "mpv.exe"},
-		{backend: "ffplay", name: "ffplay"},
-		{backend: "ffplay", name: "ffplay.exe"},
+// Title returns the stream title the player printed for the current stream.
+func (p *Player) Title() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cmd == nil {
+		return ""
 	}
+	return p.title
+}
 
-	for _, candidate := range candidates {
-		path := filepath.Join(dir, candidate.name)
-		if isExecutable(path) {
-			return path, candidate.backend
+// titleParsers pick the stream title out of one line of player output.
+var titleParsers = map[string]func(line string) string{
+	// mpv lists changed tags as " icy-title: Artist - Song".
+	"mpv": func(line string) string {
+		title, ok := strings.CutPrefix(strings.TrimSpace(line), "icy-title:")
+		if !ok {
+			return ""
 		}
+		return strings.TrimSpace(title)
+	},
+	// ffplay dumps metadata as "    StreamTitle     : Artist - Song".
+	"ffplay": func(line string) string {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != "StreamTitle" {
+			return ""
+		}
+		return strings.TrimSpace(value)
+	},
+}
+
+// maxTitleLine bounds how much of an unterminated output line is kept.
+const maxTitleLine = 4096
+
+// titleWriter splits player output into lines and reports stream titles.
+type titleWriter struct {
+	parse func(line string) string
+	set   func(title string)
+	line  []byte
+}
+
+func (w *titleWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b != '\n' && b != '\r' {
+			if len(w.line) < maxTitleLine {
+				w.line = append(w.line, b)
+			}
+			continue
+		}
+		if title := w.parse(string(w.line)); title != "" {
+			w.set(title)
+		}
+		w.line = w.line[:0]
 	}
+	return len(p), nil
+}
+
+func findBundledPlayer(backend string) string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return findPlayerIn(filepath.Dir(exe), backend)
+}
 
-	return "", ""
+func findPlayerIn(dir string, backend string) string {
+	for _, name := range executableNames[backend] {
+		for _, candidate := range []string{name, name + ".exe"} {
+			path := filepath.Join(dir, candidate)
+			if isExecutable(path) {
+				return path
+			}
+		}
+	}
+	return ""
 }
 
 func isExecutable(path string) bool {
@@ -166,14 +166,16 @@ func TestPlayer_ConcurrentAccess(t *testing.T) {
 	}
 }
 
-func TestNewExternal_NoPlayerAvailable(t *testing.T) {
+func TestNewExternalBackend_NoPlayerAvailable(t *testing.T) {
 	// This test documents the behavior when no player is found
 	// In most test environments, mpv/ffplay may not be installed
 	// The function should either return a player or an error, never panic
 
-	player, err := newExternal()
-	// Either player is found or error is returned
-	if player == nil && err == nil {
-		t.Error("newExternal() should return either a player or an error")
+	for _, backend := range []string{"mpv", "ffplay", "vlc", "gstreamer", "unknown"} {
+		player, err := newExternalBackend(backend)
+		// Either player is found or error is returned
+		if player == nil && err == nil {
+			t.Errorf("newExternalBackend(%q) should return either a player or an error", backend)
+		}
 	}
 }
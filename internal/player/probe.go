@@ -0,0 +1,110 @@
+package player
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// probeSamples is how much audio Probe decodes to prove a stream plays.
+const probeSamples = 4096
+
+// ProbeResult describes a stream that answered a Probe.
+type ProbeResult struct {
+	ContentType string
+	// Codec is a best guess from the content type, e.g. "MP3" or "AAC".
+	Codec string
+	// Bitrate is the kbps announced in the icy-br header, if any.
+	Bitrate int
+	// Name is the stream's icy-name header, if any.
+	Name string
+	// Decoded reports whether audio was actually decoded; codecs the Go
+	// decoders do not handle are only checked for an audio content type.
+	Decoded bool
+}
+
+// Probe opens url and checks that it serves audio, decoding the first
+// samples when the codec is supported. It never plays anything.
+func Probe(ctx context.Context, url string) (ProbeResult, error) {
+	if url == "" {
+		return ProbeResult{}, errors.New("stream url is required")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("User-Agent", "ValveFM/1.0")
+	req.Header.Set("Icy-MetaData", "0")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("stream open: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ProbeResult{}, fmt.Errorf("stream HTTP %d", resp.StatusCode)
+	}
+
+	contentType := strings.ToLower(strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0]))
+	result := ProbeResult{
+		ContentType: contentType,
+		Codec:       codecForContentType(contentType),
+		Name:        strings.TrimSpace(resp.Header.Get("icy-name")),
+	}
+	if bitrate, err := strconv.Atoi(strings.TrimSpace(resp.Header.Get("icy-br"))); err == nil {
+		result.Bitrate = bitrate
+	}
+
+	switch result.Codec {
+	case "MP3", "WAV", "":
+		streamer, _, err := decodeStream(resp, url)
+		if err != nil {
+			if result.Codec == "" {
+				return result, fmt.Errorf("not an audio stream (%s)", fallbackContentType(contentType))
+			}
+			return result, err
+		}
+		defer streamer.Close()
+		samples := make([][2]float64, probeSamples)
+		if n, _ := streamer.Stream(samples); n == 0 {
+			if err := streamer.Err(); err != nil {
+				return result, fmt.Errorf("decode: %w", err)
+			}
+			return result, errors.New("stream ended before any audio")
+		}
+		if result.Codec == "" {
+			result.Codec = "MP3"
+		}
+		result.Decoded = true
+	}
+	return result, nil
+}
+
+func codecForContentType(contentType string) string {
+	switch {
+	case contentType == "audio/mpeg" || contentType == "audio/mp3":
+		return "MP3"
+	case strings.Contains(contentType, "wav"):
+		return "WAV"
+	case strings.Contains(contentType, "aac"):
+		return "AAC"
+	case strings.Contains(contentType, "ogg") || strings.Contains(contentType, "opus"):
+		return "OGG"
+	case strings.Contains(contentType, "flac"):
+		return "FLAC"
+	case strings.Contains(contentType, "mpegurl"):
+		return "HLS"
+	}
+	return ""
+}
+
+func fallbackContentType(contentType string) string {
+	if contentType == "" {
+		return "no content type"
+	}
+	return contentType
+}
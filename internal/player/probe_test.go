@@ -0,0 +1,60 @@
+package player
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProbe_DecodesWAV(t *testing.T) {
+	server := newWAVServer(t, testWAV(t, 22050))
+
+	result, err := Probe(context.Background(), server.URL+"/live.wav")
+	if err != nil {
+		t.Fatalf("Probe() error = %v", err)
+	}
+	if !result.Decoded || result.Codec != "WAV" || result.ContentType != "audio/wav" {
+		t.Errorf("Probe() = %+v", result)
+	}
+}
+
+func TestProbe_Failures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html><body>Listen live!</body></html>"))
+		case "/aac":
+			w.Header().Set("Content-Type", "audio/aacp")
+			w.Header().Set("icy-br", "64")
+			w.Header().Set("icy-name", "AAC Radio")
+			w.Write([]byte("not decoded"))
+		}
+	}))
+	defer server.Close()
+
+	if _, err := Probe(context.Background(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Probe(404) error = %v", err)
+	}
+	if _, err := Probe(context.Background(), server.URL+"/page"); err == nil || !strings.Contains(err.Error(), "not an audio stream (text/html)") {
+		t.Errorf("Probe(html) error = %v", err)
+	}
+
+	result, err := Probe(context.Background(), server.URL+"/aac")
+	if err != nil {
+		t.Fatalf("Probe(aac) error = %v", err)
+	}
+	if result.Codec != "AAC" || result.Bitrate != 64 || result.Name != "AAC Radio" || result.Decoded {
+		t.Errorf("Probe(aac) = %+v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Probe(ctx, server.URL+"/aac"); err == nil {
+		t.Error("Probe() should honour a cancelled context")
+	}
+}
@@ -0,0 +1,145 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory constructs a backend. It returns an error when the backend is not
+// usable on this system (e.g. the executable is not installed).
+type Factory func(opts Options) (Backend, error)
+
+// DefaultPriority is the order backends are tried in when no priority is configured.
+var DefaultPriority = []string{"go", "mpv", "ffplay", "vlc", "gstreamer"}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]Factory{}
+)
+
+func init() {
+	Register("go", func(Options) (Backend, error) { return NewGoPlayer(), nil })
+	Register("mpv", externalFactory("mpv"))
+	Register("ffplay", externalFactory("ffplay"))
+	Register("vlc", externalFactory("vlc"))
+	Register("gstreamer", externalFactory("gstreamer"))
+	Register("null", func(Options) (Backend, error) { return NewNullPlayer(), nil })
+	Register("file", func(opts Options) (Backend, error) {
+		if strings.TrimSpace(opts.SinkPath) == "" {
+			return nil, errors.New("file sink requires a path")
+		}
+		return NewFileSink(opts.SinkPath), nil
+	})
+}
+
+// Register adds or replaces a backend factory under the given name.
+func Register(name string, factory Factory) {
+	name = normalizeName(name)
+	if name == "" || factory == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Registered returns the names of all registered backends, sorted.
+func Registered() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupFactory(name string) (Factory, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	factory, ok := registry[normalizeName(name)]
+	return factory, ok
+}
+
+// Options controls how NewWithOptions assembles the composite backend.
+type Options struct {
+	// Priority lists backend names in the order they should be tried.
+	// An empty list means DefaultPriority.
+	Priority []string
+	// Codecs overrides Priority for specific codecs (e.g. "aac" -> ["mpv"]).
+	Codecs map[string][]string
+	// SinkPath is the WAV file written by the "file" backend.
+	SinkPath string
+}
+
+// NewWithOptions builds a composite backend from the registry, honouring the
+// configured priority and per-codec overrides. Backends whose factory fails are skipped.
+func NewWithOptions(opts Options) (Backend, error) {
+	order := normalizeNames(opts.Priority)
+	if len(order) == 0 {
+		order = DefaultPriority
+	}
+
+	codecs := map[string][]string{}
+	for codec, names := range opts.Codecs {
+		codec = normalizeName(codec)
+		if names = normalizeNames(names); codec != "" && len(names) > 0 {
+			codecs[codec] = names
+		}
+	}
+
+	wanted := append([]string{}, order...)
+	for _, names := range codecs {
+		wanted = append(wanted, names...)
+	}
+
+	available := map[string]Backend{}
+	var unknown []string
+	for _, name := range wanted {
+		if _, seen := available[name]; seen {
+			continue
+		}
+		factory, ok := lookupFactory(name)
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		backend, err := factory(opts)
+		if err != nil || backend == nil {
+			continue
+		}
+		available[name] = backend
+	}
+
+	if len(available) == 0 {
+		if len(unknown) > 0 {
+			return nil, fmt.Errorf("no player backend available (unknown backends: %s)", strings.Join(unknown, ", "))
+		}
+		return nil, fmt.Errorf("no player backend available")
+	}
+
+	return &CompositeBackend{
+		order:     order,
+		codecs:    codecs,
+		available: available,
+	}, nil
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func normalizeNames(names []string) []string {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = normalizeName(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
@@ -0,0 +1,140 @@
+package player
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func registerMock(t *testing.T, name string, mock *mockBackend) {
+	t.Helper()
+	Register(name, func(Options) (Backend, error) { return mock, nil })
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(registry, name)
+		registryMu.Unlock()
+	})
+}
+
+func TestRegistered_IncludesBuiltins(t *testing.T) {
+	names := Registered()
+	for _, want := range []string{"go", "mpv", "ffplay", "vlc", "gstreamer", "null", "file"} {
+		found := false
+		for _, name := range names {
+			if name == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Registered() missing %q, got %v", want, names)
+		}
+	}
+}
+
+func TestNewWithOptions_FallsBackInPriorityOrder(t *testing.T) {
+	first := &mockBackend{playErr: errors.New("unsupported")}
+	second := &mockBackend{}
+	registerMock(t, "test-first", first)
+	registerMock(t, "test-second", second)
+
+	backend, err := NewWithOptions(Options{Priority: []string{"Test-First", " test-second "}})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	if err := backend.Play("http://example.com/stream"); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	if first.playCalls != 1 || second.playCalls != 1 {
+		t.Errorf("playCalls = %d/%d, want 1/1", first.playCalls, second.playCalls)
+	}
+	if name := backend.(NamedBackend).Name(); name != "test-second" {
+		t.Errorf("Name() = %q, want %q", name, "test-second")
+	}
+	if !backend.IsPlaying() {
+		t.Error("IsPlaying() should be true after fallback succeeded")
+	}
+}
+
+func TestNewWithOptions_CodecOverride(t *testing.T) {
+	general := &mockBackend{}
+	aac := &mockBackend{}
+	registerMock(t, "test-general", general)
+	registerMock(t, "test-aac", aac)
+
+	backend, err := NewWithOptions(Options{
+		Priority: []string{"test-general"},
+		Codecs:   map[string][]string{"AAC": {"test-aac"}},
+	})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	cp := backend.(CodecPlayer)
+	if err := cp.PlayCodec("http://example.com/aac", "aac"); err != nil {
+		t.Fatalf("PlayCodec() error = %v", err)
+	}
+	if aac.playCalls != 1 || general.playCalls != 0 {
+		t.Errorf("playCalls aac/general = %d/%d, want 1/0", aac.playCalls, general.playCalls)
+	}
+
+	if err := cp.PlayCodec("http://example.com/mp3", "MP3"); err != nil {
+		t.Fatalf("PlayCodec() error = %v", err)
+	}
+	if general.playCalls != 1 {
+		t.Errorf("general playCalls = %d, want 1", general.playCalls)
+	}
+	if aac.stopCalls != 1 {
+		t.Errorf("previous backend should be stopped, stopCalls = %d", aac.stopCalls)
+	}
+}
+
+func TestNewWithOptions_SkipsUnavailable(t *testing.T) {
+	working := &mockBackend{}
+	Register("test-broken", func(Options) (Backend, error) { return nil, errors.New("not installed") })
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(registry, "test-broken")
+		registryMu.Unlock()
+	})
+	registerMock(t, "test-working", working)
+
+	backend, err := NewWithOptions(Options{Priority: []string{"test-broken", "does-not-exist", "test-working"}})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	got := backend.(*CompositeBackend).Backends()
+	if !reflect.DeepEqual(got, []string{"test-working"}) {
+		t.Errorf("Backends() = %v, want [test-working]", got)
+	}
+}
+
+func TestNewWithOptions_NothingAvailable(t *testing.T) {
+	_, err := NewWithOptions(Options{Priority: []string{"does-not-exist"}})
+	if err == nil {
+		t.Fatal("NewWithOptions() should fail when no backend can be constructed")
+	}
+}
+
+func TestCompositeBackend_Play_CollectsErrors(t *testing.T) {
+	cb := &CompositeBackend{
+		order: []string{"a", "b"},
+		available: map[string]Backend{
+			"a": &mockBackend{playErr: errors.New("boom")},
+			"b": &mockBackend{playErr: errors.New("bang")},
+		},
+	}
+
+	err := cb.Play("http://example.com/stream")
+	if err == nil {
+		t.Fatal("Play() should fail when every backend fails")
+	}
+	if err.Error() != "a: boom, b: bang" {
+		t.Errorf("Play() error = %q, want %q", err.Error(), "a: boom, b: bang")
+	}
+	if cb.Name() != "" {
+		t.Errorf("Name() = %q, want empty after failure", cb.Name())
+	}
+}
@@ -0,0 +1,262 @@
+package player
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/mp3"
+	"github.com/gopxl/beep/v2/wav"
+)
+
+// SinkPlayer decodes HTTP streams without a sound card. Decoded PCM is
+// either discarded or written to a WAV file, which makes the full play path
+// usable on headless machines and in tests.
+type SinkPlayer struct {
+	mu      sync.Mutex
+	name    string
+	path    string
+	client  *http.Client
+	resp    *http.Response
+	icy     *icyReader
+	stop    *atomic.Bool
+	done    chan struct{}
+	lastURL string
+	lastErr error
+	samples atomic.Int64
+}
+
+// streamHeaderTimeout bounds how long Play waits for a stream to answer;
+// Play holds the player's lock meanwhile. The stream body itself has no
+// deadline since live streams never end.
+const streamHeaderTimeout = 15 * time.Second
+
+// newStreamClient returns an HTTP client that gives up on streams whose
+// server accepts the connection but never answers.
+func newStreamClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = streamHeaderTimeout
+	return &http.Client{Transport: transport}
+}
+
+// NewNullPlayer creates a sink that decodes streams and discards the audio.
+func NewNullPlayer() *SinkPlayer {
+	return &SinkPlayer{name: "null", client: newStreamClient()}
+}
+
+// NewFileSink creates a sink that writes decoded audio to a WAV file at path.
+// The file is rewritten every time a new stream starts.
+func NewFileSink(path string) *SinkPlayer {
+	return &SinkPlayer{name: "file", path: path, client: newStreamClient()}
+}
+
+// Play opens the stream, decodes its header and starts consuming it in the background.
+func (s *SinkPlayer) Play(url string) error {
+	if url == "" {
+		return errors.New("stream url is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The previous stream must finish finalizing its WAV file before the
+	// file is recreated, and consume needs s.mu to exit.
+	for {
+		done := s.stopLocked()
+		if done == nil {
+			break
+		}
+		s.mu.Unlock()
+		<-done
+		s.mu.Lock()
+	}
+	s.lastURL = url
+	s.icy = nil
+	s.lastErr = nil
+	s.samples.Store(0)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	req.Header.Set("User-Agent", "ValveFM/1.0")
+	req.Header.Set("Icy-MetaData", "1")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("stream open: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return fmt.Errorf("stream HTTP %d", resp.StatusCode)
+	}
+	icy := watchICY(resp)
+
+	streamer, format, err := decodeStream(resp, url)
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+
+	var out *os.File
+	if s.path != "" {
+		out, err = os.Create(s.path)
+		if err != nil {
+			streamer.Close()
+			resp.Body.Close()
+			return fmt.Errorf("sink file: %w", err)
+		}
+	}
+
+	stop := &atomic.Bool{}
+	done := make(chan struct{})
+	s.resp = resp
+	s.icy = icy
+	s.stop = stop
+	s.done = done
+
+	go s.consume(streamer, format, out, stop, done)
+	return nil
+}
+
+func (s *SinkPlayer) consume(streamer beep.StreamSeekCloser, format beep.Format, out *os.File, stop *atomic.Bool, done chan struct{}) {
+	defer close(done)
+	defer streamer.Close()
+
+	counted := &countingStreamer{Streamer: streamer, stop: stop, samples: &s.samples}
+
+	var err error
+	if out != nil {
+		format.Precision = 2
+		err = wav.Encode(out, counted, format)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	} else {
+		buf := make([][2]float64, 512)
+		for {
+			if _, ok := counted.Stream(buf); !ok {
+				break
+			}
+		}
+	}
+	if err == nil && !stop.Load() {
+		err = streamer.Err()
+	}
+
+	s.mu.Lock()
+	if s.stop == stop {
+		s.lastErr = err
+		s.resp = nil
+		s.stop = nil
+	}
+	if s.done == done {
+		s.done = nil
+	}
+	s.mu.Unlock()
+}
+
+// Stop ends the current stream and waits for the sink file to be finalized.
+func (s *SinkPlayer) Stop() error {
+	s.mu.Lock()
+	done := s.stopLocked()
+	s.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+	return nil
+}
+
+// stopLocked signals the current stream to end and returns the done channel
+// of a consumer that has not exited yet. The channel stays set until consume
+// returns, so every caller waits for the sink file to be finalized.
+func (s *SinkPlayer) stopLocked() chan struct{} {
+	if s.stop != nil {
+		s.stop.Store(true)
+		if s.resp != nil {
+			s.resp.Body.Close()
+		}
+		s.resp = nil
+		s.stop = nil
+	}
+	return s.done
+}
+
+func (s *SinkPlayer) IsPlaying() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stop != nil
+}
+
+func (s *SinkPlayer) LastURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastURL
+}
+
+func (s *SinkPlayer) Name() string {
+	return s.name
+}
+
+// Title returns the stream title read from the current stream.
+func (s *SinkPlayer) Title() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.icy.Title()
+}
+
+// Samples returns how many stereo frames have been decoded from the current stream.
+func (s *SinkPlayer) Samples() int64 {
+	return s.samples.Load()
+}
+
+// Err returns the error that ended the previous stream, if any.
+func (s *SinkPlayer) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastErr
+}
+
+// countingStreamer stops streaming once stop is set and tallies decoded frames.
+type countingStreamer struct {
+	beep.Streamer
+	stop    *atomic.Bool
+	samples *atomic.Int64
+}
+
+func (c *countingStreamer) Stream(samples [][2]float64) (int, bool) {
+	if c.stop.Load() {
+		return 0, false
+	}
+	n, ok := c.Streamer.Stream(samples)
+	c.samples.Add(int64(n))
+	return n, ok
+}
+
+func decodeStream(resp *http.Response, url string) (beep.StreamSeekCloser, beep.Format, error) {
+	contentType := strings.ToLower(resp.Header.Get("Content-Type"))
+	ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0]))
+
+	if strings.Contains(contentType, "wav") || ext == ".wav" {
+		streamer, format, err := wav.Decode(resp.Body)
+		if err != nil {
+			return nil, beep.Format{}, fmt.Errorf("wav decode: %w", err)
+		}
+		return streamer, format, nil
+	}
+
+	streamer, format, err := mp3.Decode(resp.Body)
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("mp3 decode: %w", err)
+	}
+	return streamer, format, nil
+}
+
+var _ Backend = (*SinkPlayer)(nil)
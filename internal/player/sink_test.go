@@ -0,0 +1,251 @@
+package player
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/generators"
+	"github.com/gopxl/beep/v2/wav"
+)
+
+// testWAV encodes frames of a sine tone as a WAV file and returns its bytes.
+func testWAV(t *testing.T, frames int) []byte {
+	t.Helper()
+	format := beep.Format{SampleRate: 22050, NumChannels: 2, Precision: 2}
+	tone, err := generators.SineTone(format.SampleRate, 440)
+	if err != nil {
+		t.Fatalf("SineTone() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := wav.Encode(f, beep.Take(frames, tone), format); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return data
+}
+
+func newWAVServer(t *testing.T, data []byte) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNullPlayer_DecodesStream(t *testing.T) {
+	server := newWAVServer(t, testWAV(t, 4096))
+
+	sink := NewNullPlayer()
+	if err := sink.Play(server.URL + "/live"); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	if sink.LastURL() != server.URL+"/live" {
+		t.Errorf("LastURL() = %q", sink.LastURL())
+	}
+
+	// The finite stream ends on its own once all frames are consumed.
+	waitFor(t, func() bool { return !sink.IsPlaying() })
+	if got := sink.Samples(); got != 4096 {
+		t.Errorf("Samples() = %d, want 4096", got)
+	}
+	if err := sink.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	if sink.Name() != "null" {
+		t.Errorf("Name() = %q, want null", sink.Name())
+	}
+}
+
+func TestFileSink_WritesWAV(t *testing.T) {
+	server := newWAVServer(t, testWAV(t, 2048))
+	out := filepath.Join(t.TempDir(), "out.wav")
+
+	sink := NewFileSink(out)
+	if err := sink.Play(server.URL); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	waitFor(t, func() bool { return !sink.IsPlaying() })
+	if err := sink.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+	streamer, format, err := wav.Decode(f)
+	if err != nil {
+		t.Fatalf("wav.Decode() error = %v", err)
+	}
+	if format.SampleRate != 22050 || format.NumChannels != 2 {
+		t.Errorf("format = %+v, want 22050Hz stereo", format)
+	}
+	if streamer.Len() != 2048 {
+		t.Errorf("Len() = %d, want 2048", streamer.Len())
+	}
+}
+
+func TestSinkPlayer_StopInterruptsStream(t *testing.T) {
+	data := testWAV(t, 1024)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		// Send only the header and first frames, then hang like a live stream.
+		w.Write(data[:len(data)/2])
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sink := NewNullPlayer()
+	if err := sink.Play(server.URL); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	waitFor(t, func() bool { return sink.Samples() > 0 })
+	if !sink.IsPlaying() {
+		t.Fatal("IsPlaying() should be true while the stream is open")
+	}
+
+	if err := sink.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if sink.IsPlaying() {
+		t.Error("IsPlaying() should be false after Stop()")
+	}
+}
+
+func TestSinkPlayer_Play_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write([]byte("not a wav file"))
+	}))
+	defer server.Close()
+
+	sink := NewNullPlayer()
+	if err := sink.Play(""); err == nil {
+		t.Error("Play() should fail for empty URL")
+	}
+	if err := sink.Play(server.URL + "/missing"); err == nil {
+		t.Error("Play() should fail for HTTP 404")
+	}
+	if err := sink.Play(server.URL + "/garbage"); err == nil {
+		t.Error("Play() should fail for undecodable stream")
+	}
+	if sink.IsPlaying() {
+		t.Error("IsPlaying() should be false after failed Play()")
+	}
+}
+
+func TestSinkPlayer_Play_HeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sink := NewNullPlayer()
+	sink.client.Transport.(*http.Transport).ResponseHeaderTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	if err := sink.Play(server.URL); err == nil {
+		t.Fatal("Play() should fail when the server never answers")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Play() took %v to give up", elapsed)
+	}
+}
+
+func TestNewWithOptions_FileSinkRequiresPath(t *testing.T) {
+	if _, err := NewWithOptions(Options{Priority: []string{"file"}}); err == nil {
+		t.Error("NewWithOptions() should fail for file sink without a path")
+	}
+
+	backend, err := NewWithOptions(Options{Priority: []string{"file"}, SinkPath: filepath.Join(t.TempDir(), "x.wav")})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	if got := backend.(*CompositeBackend).Backends(); len(got) != 1 || got[0] != "file" {
+		t.Errorf("Backends() = %v, want [file]", got)
+	}
+}
+
+func TestFileSink_SwitchStreamKeepsNewFile(t *testing.T) {
+	live := testWAV(t, 4096)
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		w.Write(live[:len(live)/2])
+		w.(http.Flusher).Flush()
+		<-release
+	}))
+	defer hanging.Close()
+	defer close(release)
+	finite := newWAVServer(t, testWAV(t, 1024))
+	out := filepath.Join(t.TempDir(), "out.wav")
+
+	sink := NewFileSink(out)
+	if err := sink.Play(hanging.URL); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	waitFor(t, func() bool { return sink.Samples() > 0 })
+
+	// Switching must not let the old stream finalize over the new file.
+	if err := sink.Play(finite.URL); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	waitFor(t, func() bool { return !sink.IsPlaying() })
+	if err := sink.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+	streamer, _, err := wav.Decode(f)
+	if err != nil {
+		t.Fatalf("wav.Decode() error = %v", err)
+	}
+	if streamer.Len() != 1024 {
+		t.Errorf("Len() = %d, want 1024", streamer.Len())
+	}
+}
@@ -0,0 +1,139 @@
+package playlist
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+const favoritesUsage = `usage:
+  valvefm favorites export [-format m3u|pls|opml|xspf|json] [file]
+  valvefm favorites import [-format m3u|pls|opml|xspf|json] [-offline] file
+
+Export writes to standard output when file is omitted or "-". The format
+follows the file extension and is otherwise M3U. Import detects
+the format from the content when the extension does not name one.`
+
+// RunFavoritesCommand runs the "favorites" command line, which exports the
+// favorites to a playlist or imports one, and reports on the terminal.
+func RunFavoritesCommand(args []string, api *radio.Client) error {
+	if len(args) == 0 {
+		return errors.New(favoritesUsage)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	favs, err := config.LoadFavorites()
+	if err != nil {
+		return fmt.Errorf("load favorites: %w", err)
+	}
+	switch args[0] {
+	case "export":
+		return runExport(ctx, args[1:], favs, api)
+	case "import":
+		return runImport(ctx, args[1:], favs, api)
+	}
+	return errors.New(favoritesUsage)
+}
+
+func runExport(ctx context.Context, args []string, favs *config.Favorites, api *radio.Client) error {
+	flags := flag.NewFlagSet("favorites export", flag.ContinueOnError)
+	formatFlag := flags.String("format", "", "playlist format")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	path := flags.Arg(0)
+	format, err := commandFormat(*formatFlag, path)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = M3U
+	}
+
+	var result ExportResult
+	if path == "" || path == "-" {
+		result, err = ExportFavorites(ctx, os.Stdout, format, favs, api)
+	} else {
+		var f *os.File
+		if f, err = os.Create(path); err != nil {
+			return err
+		}
+		result, err = ExportFavorites(ctx, f, format, favs, api)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d favorites", result.Written)
+	if result.Skipped > 0 {
+		fmt.Fprintf(os.Stderr, ", skipped %d without a stream url", result.Skipped)
+	}
+	fmt.Fprintln(os.Stderr)
+	return nil
+}
+
+func runImport(ctx context.Context, args []string, favs *config.Favorites, api *radio.Client) error {
+	flags := flag.NewFlagSet("favorites import", flag.ContinueOnError)
+	formatFlag := flags.String("format", "", "playlist format")
+	offline := flags.Bool("offline", false, "add every entry as a custom station without asking Radio Browser")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	path := flags.Arg(0)
+	if path == "" {
+		return errors.New(favoritesUsage)
+	}
+	format, err := commandFormat(*formatFlag, path)
+	if err != nil {
+		return err
+	}
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	entries, err := Read(in, format)
+	if err != nil {
+		return err
+	}
+	if *offline {
+		api = nil
+	}
+	result, err := ImportFavorites(ctx, entries, favs, api)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "imported %d favorites: %d matched on Radio Browser, %d custom; %d already saved, %d skipped\n",
+		result.Added(), result.Matched, result.Custom, result.Duplicates, result.Skipped)
+	return nil
+}
+
+// commandFormat prefers an explicit -format over the file extension. It
+// returns an empty format when neither names one.
+func commandFormat(name string, path string) (Format, error) {
+	if name != "" {
+		return ParseFormat(name)
+	}
+	if path == "" || path == "-" {
+		return "", nil
+	}
+	if format, err := FormatFromPath(path); err == nil {
+		return format, nil
+	}
+	return "", nil
+}
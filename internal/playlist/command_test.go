@@ -0,0 +1,47 @@
+package playlist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func TestRunFavoritesCommand_ExportThenImport(t *testing.T) {
+	favs := newTestFavorites(t)
+	if _, err := favs.Toggle(radio.Station{UUID: "a", Name: "Alpha", URL: "http://alpha.example.com/"}); err != nil {
+		t.Fatalf("Toggle() error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "favorites.pls")
+
+	if err := RunFavoritesCommand([]string{"export", path}, nil); err != nil {
+		t.Fatalf("export error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), "[playlist]\nFile1=http://alpha.example.com/\nTitle1=Alpha\n") {
+		t.Errorf("export = %q", data)
+	}
+
+	// A second profile imports the file offline.
+	newTestFavorites(t)
+	if err := RunFavoritesCommand([]string{"import", "-offline", path}, nil); err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	imported, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	if imported.Count() != 1 || imported.List()[0].Name != "Alpha" {
+		t.Errorf("imported favorites = %+v", imported.List())
+	}
+
+	if err := RunFavoritesCommand([]string{"rename"}, nil); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("unknown command error = %v, want usage", err)
+	}
+}
@@ -0,0 +1,215 @@
+package playlist
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+// ExportResult summarises ExportFavorites.
+type ExportResult struct {
+	Written int
+	// Skipped counts favorites whose stream URL is unknown.
+	Skipped int
+}
+
+// ExportFavorites writes favs to w with their resolved stream URLs.
+// Favorites saved without a station record are looked up through api
+// first; with a nil api they are skipped.
+func ExportFavorites(ctx context.Context, w io.Writer, format Format, favs *config.Favorites, api *radio.Client) (ExportResult, error) {
+	list := favs.List()
+	stations := make(map[string]radio.Station, len(list))
+	var unknown []string
+	for _, fav := range list {
+		if fav.Station != nil {
+			station := *fav.Station
+			station.UUID = fav.UUID
+			if _, err := radio.StreamURL(station); err == nil {
+				stations[fav.UUID] = station
+				continue
+			}
+		}
+		unknown = append(unknown, fav.UUID)
+	}
+	if len(unknown) > 0 && api != nil {
+		found, err := api.StationsByUUIDs(ctx, unknown)
+		if err != nil {
+			return ExportResult{}, fmt.Errorf("look up stream urls: %w", err)
+		}
+		for _, station := range found {
+			stations[station.UUID] = station
+		}
+	}
+
+	var result ExportResult
+	entries := make([]Entry, 0, len(list))
+	for _, fav := range list {
+		station, ok := stations[fav.UUID]
+		streamURL, err := radio.StreamURL(station)
+		if !ok || err != nil {
+			result.Skipped++
+			continue
+		}
+		entry := Entry{
+			Name:        fallback(station.Name, fav.Name),
+			URL:         streamURL,
+			Homepage:    station.Homepage,
+			Tags:        fallback(station.Tags, fav.Tags),
+			CountryCode: station.CountryCode,
+		}
+		if !station.Custom() {
+			entry.UUID = station.UUID
+		}
+		entries = append(entries, entry)
+	}
+	if err := Write(w, format, entries); err != nil {
+		return ExportResult{}, err
+	}
+	result.Written = len(entries)
+	return result, nil
+}
+
+// ImportResult summarises ImportFavorites.
+type ImportResult struct {
+	// Matched counts entries found on Radio Browser.
+	Matched int
+	// Custom counts entries added as custom stations.
+	Custom int
+	// Duplicates counts entries that were already favorites.
+	Duplicates int
+	// Skipped counts entries that are not http(s) streams.
+	Skipped int
+}
+
+// Added is the number of new favorites.
+func (r ImportResult) Added() int {
+	return r.Matched + r.Custom
+}
+
+// ImportFavorites adds entries to favs. Entries are matched back to Radio
+// Browser stations by their recorded UUID or else by stream URL; the rest
+// become custom stations. A nil api skips matching. Nothing is saved when
+// a lookup fails.
+func ImportFavorites(ctx context.Context, entries []Entry, favs *config.Favorites, api *radio.Client) (ImportResult, error) {
+	known := make(map[string]bool)
+	for _, fav := range favs.List() {
+		known[fav.UUID] = true
+		if fav.Station != nil {
+			if streamURL, err := radio.StreamURL(*fav.Station); err == nil {
+				known[streamURL] = true
+			}
+		}
+	}
+
+	byUUID := map[string]radio.Station{}
+	if api != nil {
+		var uuids []string
+		for _, entry := range entries {
+			if entry.UUID != "" && !radio.IsCustomUUID(entry.UUID) {
+				uuids = append(uuids, entry.UUID)
+			}
+		}
+		found, err := api.StationsByUUIDs(ctx, uuids)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		for _, station := range found {
+			byUUID[station.UUID] = station
+		}
+	}
+
+	var (
+		result ImportResult
+		added  []radio.Station
+	)
+	for _, entry := range entries {
+		if !isStreamURL(entry.URL) {
+			result.Skipped++
+			continue
+		}
+		if known[entry.URL] {
+			result.Duplicates++
+			continue
+		}
+		station, matched := byUUID[entry.UUID]
+		if !matched && api != nil {
+			var err error
+			station, matched, err = matchURL(ctx, api, entry.URL)
+			if err != nil {
+				return ImportResult{}, err
+			}
+		}
+		if matched {
+			known[entry.URL] = true
+			if known[station.UUID] {
+				result.Duplicates++
+				continue
+			}
+			known[station.UUID] = true
+			added = append(added, station)
+			result.Matched++
+			continue
+		}
+
+		station, err := customStation(entry)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		known[entry.URL] = true
+		added = append(added, station)
+		result.Custom++
+	}
+
+	// A cancelled import must not change the favorites.
+	if err := ctx.Err(); err != nil {
+		return ImportResult{}, err
+	}
+	if _, err := favs.Merge(added); err != nil {
+		return ImportResult{}, err
+	}
+	return result, nil
+}
+
+// matchURL looks up the station streaming from streamURL, preferring an
+// exact match when the directory returns several.
+func matchURL(ctx context.Context, api *radio.Client, streamURL string) (radio.Station, bool, error) {
+	stations, err := api.StationsByURL(ctx, streamURL)
+	if err != nil || len(stations) == 0 {
+		return radio.Station{}, false, err
+	}
+	for _, station := range stations {
+		if station.URL == streamURL || station.URLResolved == streamURL {
+			return station, true, nil
+		}
+	}
+	return stations[0], true, nil
+}
+
+// customStation keeps what the playlist said about a station; details the
+// directory would reject are dropped rather than losing the entry.
+func customStation(entry Entry) (radio.Station, error) {
+	full := radio.NewStation{
+		Name:        entry.Name,
+		URL:         entry.URL,
+		Homepage:    entry.Homepage,
+		CountryCode: entry.CountryCode,
+	}
+	if tags := strings.TrimSpace(entry.Tags); tags != "" {
+		full.Tags = strings.Split(tags, ",")
+	}
+	if station, err := full.CustomStation(); err == nil {
+		return station, nil
+	}
+	return radio.NewStation{Name: entry.Name, URL: entry.URL}.CustomStation()
+}
+
+func isStreamURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,165 @@
+package playlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func newTestFavorites(t *testing.T) *config.Favorites {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	favs, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	return favs
+}
+
+// newDirectory serves byuuid and byurl lookups for stations.
+func newDirectory(t *testing.T, stations ...radio.Station) *radio.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		found := []radio.Station{}
+		switch r.URL.Path {
+		case "/json/stations/byuuid":
+			uuids := strings.Split(r.URL.Query().Get("uuids"), ",")
+			for _, station := range stations {
+				for _, uuid := range uuids {
+					if station.UUID == uuid {
+						found = append(found, station)
+					}
+				}
+			}
+		case "/json/stations/byurl":
+			for _, station := range stations {
+				if station.URL == r.URL.Query().Get("url") {
+					found = append(found, station)
+				}
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(found)
+	}))
+	t.Cleanup(server.Close)
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return api
+}
+
+func TestImportFavorites(t *testing.T) {
+	favs := newTestFavorites(t)
+	if _, err := favs.Toggle(radio.Station{UUID: "existing", Name: "Existing", URL: "http://existing.example.com/"}); err != nil {
+		t.Fatalf("Toggle() error = %v", err)
+	}
+	api := newDirectory(t,
+		radio.Station{UUID: "by-url", Name: "Listed Name", URL: "http://listed.example.com/"},
+		radio.Station{UUID: "by-uuid", Name: "Known", URL: "http://known.example.com/"},
+	)
+
+	result, err := ImportFavorites(context.Background(), []Entry{
+		{Name: "My Listed", URL: "http://listed.example.com/"},
+		{Name: "Known", URL: "http://moved.example.com/", UUID: "by-uuid"},
+		{Name: "Pirate", URL: "http://pirate.example.com/", CountryCode: "Germany"},
+		{Name: "Again", URL: "http://existing.example.com/"},
+		{Name: "Twice", URL: "http://pirate.example.com/"},
+		{Name: "Local file", URL: "/music/radio.mp3"},
+	}, favs, api)
+	if err != nil {
+		t.Fatalf("ImportFavorites() error = %v", err)
+	}
+	want := ImportResult{Matched: 2, Custom: 1, Duplicates: 2, Skipped: 1}
+	if result != want {
+		t.Errorf("ImportFavorites() = %+v, want %+v", result, want)
+	}
+	if !favs.IsFavorite("by-url") || !favs.IsFavorite("by-uuid") {
+		t.Error("matched stations were not added under their Radio Browser UUIDs")
+	}
+
+	var custom config.Favorite
+	for _, fav := range favs.List() {
+		if radio.IsCustomUUID(fav.UUID) {
+			custom = fav
+		}
+	}
+	if custom.Name != "Pirate" || custom.Station == nil || custom.Station.URLResolved != "http://pirate.example.com/" {
+		t.Errorf("custom favorite = %+v", custom)
+	}
+}
+
+func TestImportFavorites_Offline(t *testing.T) {
+	favs := newTestFavorites(t)
+	result, err := ImportFavorites(context.Background(), []Entry{
+		{Name: "One", URL: "http://one.example.com/", UUID: "uuid-1"},
+	}, favs, nil)
+	if err != nil {
+		t.Fatalf("ImportFavorites() error = %v", err)
+	}
+	if result.Custom != 1 || favs.Count() != 1 {
+		t.Errorf("ImportFavorites() = %+v, want one custom station", result)
+	}
+}
+
+func TestExportFavorites_ResolvesMissingURLs(t *testing.T) {
+	favs := newTestFavorites(t)
+	for _, station := range []radio.Station{
+		{UUID: "a", Name: "Alpha", URL: "http://alpha.example.com/pls", URLResolved: "http://alpha.example.com/live"},
+		{UUID: "b", Name: "Bravo"},
+		{UUID: "c", Name: "Charlie"},
+	} {
+		if _, err := favs.Toggle(station); err != nil {
+			t.Fatalf("Toggle() error = %v", err)
+		}
+	}
+	custom, err := radio.NewStation{Name: "Delta", URL: "http://delta.example.com/"}.CustomStation()
+	if err != nil {
+		t.Fatalf("CustomStation() error = %v", err)
+	}
+	if err := favs.Add(custom); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	api := newDirectory(t, radio.Station{UUID: "b", Name: "Bravo", URL: "http://bravo.example.com/"})
+
+	var buf bytes.Buffer
+	result, err := ExportFavorites(context.Background(), &buf, M3U, favs, api)
+	if err != nil {
+		t.Fatalf("ExportFavorites() error = %v", err)
+	}
+	if result != (ExportResult{Written: 3, Skipped: 1}) {
+		t.Errorf("ExportFavorites() = %+v", result)
+	}
+	want := "#EXTM3U\n" +
+		"#EXTINF:-1,Alpha\nhttp://alpha.example.com/live\n" +
+		"#EXTINF:-1,Bravo\nhttp://bravo.example.com/\n" +
+		"#EXTINF:-1,Delta\nhttp://delta.example.com/\n"
+	if buf.String() != want {
+		t.Errorf("export =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if _, err := ExportFavorites(context.Background(), &buf, JSON, favs, nil); err != nil {
+		t.Fatalf("ExportFavorites() error = %v", err)
+	}
+	entries, err := Read(&buf, JSON)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].UUID != "a" || entries[1].UUID != "" {
+		t.Errorf("JSON export = %+v, custom stations should carry no UUID", entries)
+	}
+}
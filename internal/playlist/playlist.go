@@ -0,0 +1,452 @@
+// Package playlist reads and writes station lists in the formats other
+// players use: M3U, PLS, OPML, XSPF and JSON.
+package playlist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxPlaylistSize bounds how much of a playlist file is read.
+const maxPlaylistSize = 10 << 20
+
+// Format names a playlist file format.
+type Format string
+
+const (
+	M3U  Format = "m3u"
+	PLS  Format = "pls"
+	OPML Format = "opml"
+	XSPF Format = "xspf"
+	JSON Format = "json"
+)
+
+// Formats lists the supported formats in menu order.
+var Formats = []Format{M3U, PLS, OPML, XSPF, JSON}
+
+// Entry is one station in a playlist.
+type Entry struct {
+	Name string
+	URL  string
+	// UUID is the Radio Browser station ID, when the format records one.
+	UUID        string
+	Homepage    string
+	Tags        string
+	CountryCode string
+}
+
+// ParseFormat accepts a format name such as "m3u" or "M3U8".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), ".")) {
+	case "m3u", "m3u8":
+		return M3U, nil
+	case "pls":
+		return PLS, nil
+	case "opml":
+		return OPML, nil
+	case "xspf":
+		return XSPF, nil
+	case "json":
+		return JSON, nil
+	}
+	return "", fmt.Errorf("unknown playlist format %q", name)
+}
+
+// FormatFromPath picks the format matching path's extension.
+func FormatFromPath(path string) (Format, error) {
+	ext := filepath.Ext(path)
+	if ext == "" {
+		return "", fmt.Errorf("cannot tell the playlist format of %q", path)
+	}
+	return ParseFormat(ext)
+}
+
+// Ext is the usual file extension for the format, including the dot.
+func (f Format) Ext() string {
+	return "." + string(f)
+}
+
+// Detect guesses the format from the start of a playlist. Text that is
+// nothing else is read as M3U, which also covers plain lists of URLs.
+func Detect(data []byte) Format {
+	head := bytes.TrimPrefix(bytes.TrimSpace(data), []byte("\xef\xbb\xbf"))
+	lower := strings.ToLower(string(head[:min(len(head), 512)]))
+	switch {
+	case strings.HasPrefix(lower, "[playlist]"):
+		return PLS
+	case strings.HasPrefix(lower, "[") || strings.HasPrefix(lower, "{"):
+		return JSON
+	case strings.Contains(lower, "<opml"):
+		return OPML
+	case strings.Contains(lower, "<playlist"):
+		return XSPF
+	}
+	return M3U
+}
+
+// Read parses a playlist. An empty format is detected from the content.
+func Read(r io.Reader, format Format) ([]Entry, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxPlaylistSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPlaylistSize {
+		return nil, errors.New("playlist is too large")
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if format == "" {
+		format = Detect(data)
+	}
+
+	var entries []Entry
+	switch format {
+	case M3U:
+		entries = readM3U(data)
+	case PLS:
+		entries = readPLS(data)
+	case OPML:
+		entries, err = readOPML(data)
+	case XSPF:
+		entries, err = readXSPF(data)
+	case JSON:
+		entries, err = readJSON(data)
+	default:
+		return nil, fmt.Errorf("unknown playlist format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", format, err)
+	}
+	return cleanEntries(entries), nil
+}
+
+// Write encodes entries in format.
+func Write(w io.Writer, format Format, entries []Entry) error {
+	switch format {
+	case M3U:
+		return writeM3U(w, entries)
+	case PLS:
+		return writePLS(w, entries)
+	case OPML:
+		return writeOPML(w, entries)
+	case XSPF:
+		return writeXSPF(w, entries)
+	case JSON:
+		return writeJSON(w, entries)
+	}
+	return fmt.Errorf("unknown playlist format %q", format)
+}
+
+// cleanEntries trims fields, drops entries without a URL and names the
+// rest after their URL when the playlist gave no title.
+func cleanEntries(entries []Entry) []Entry {
+	cleaned := entries[:0]
+	for _, entry := range entries {
+		entry.Name = strings.TrimSpace(entry.Name)
+		entry.URL = strings.TrimSpace(entry.URL)
+		entry.UUID = strings.TrimSpace(entry.UUID)
+		if entry.URL == "" {
+			continue
+		}
+		if entry.Name == "" {
+			entry.Name = entry.URL
+		}
+		cleaned = append(cleaned, entry)
+	}
+	return cleaned
+}
+
+func readM3U(data []byte) []Entry {
+	var (
+		entries []Entry
+		title   string
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, maxPlaylistSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			// #EXTINF:-1 tvg-logo="...",Station name
+			if _, name, ok := strings.Cut(line, ","); ok {
+				title = name
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			entries = append(entries, Entry{Name: title, URL: line})
+			title = ""
+		}
+	}
+	return entries
+}
+
+func writeM3U(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("#EXTM3U\n")
+	for _, entry := range entries {
+		fmt.Fprintf(bw, "#EXTINF:-1,%s\n%s\n", oneLine(entry.Name), entry.URL)
+	}
+	return bw.Flush()
+}
+
+func readPLS(data []byte) []Entry {
+	byNumber := map[int]*Entry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, maxPlaylistSize)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var field string
+		for _, prefix := range []string{"file", "title"} {
+			if strings.HasPrefix(key, prefix) {
+				field, key = prefix, strings.TrimPrefix(key, prefix)
+				break
+			}
+		}
+		number, err := strconv.Atoi(key)
+		if field == "" || err != nil {
+			continue
+		}
+		entry := byNumber[number]
+		if entry == nil {
+			entry = &Entry{}
+			byNumber[number] = entry
+		}
+		if field == "file" {
+			entry.URL = value
+		} else {
+			entry.Name = value
+		}
+	}
+
+	numbers := make([]int, 0, len(byNumber))
+	for number := range byNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	entries := make([]Entry, 0, len(numbers))
+	for _, number := range numbers {
+		entries = append(entries, *byNumber[number])
+	}
+	return entries
+}
+
+func writePLS(w io.Writer, entries []Entry) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("[playlist]\n")
+	for i, entry := range entries {
+		fmt.Fprintf(bw, "File%d=%s\nTitle%d=%s\nLength%d=-1\n", i+1, entry.URL, i+1, oneLine(entry.Name), i+1)
+	}
+	fmt.Fprintf(bw, "NumberOfEntries=%d\nVersion=2\n", len(entries))
+	return bw.Flush()
+}
+
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Title   string        `xml:"head>title"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+// opmlOutline covers the attribute spellings used by TuneIn, RadioTime and
+// podcast apps.
+type opmlOutline struct {
+	Text      string        `xml:"text,attr"`
+	Title     string        `xml:"title,attr,omitempty"`
+	Type      string        `xml:"type,attr,omitempty"`
+	URL       string        `xml:"URL,attr,omitempty"`
+	LowerURL  string        `xml:"url,attr,omitempty"`
+	GuideID   string        `xml:"guide_id,attr,omitempty"`
+	StationID string        `xml:"stationuuid,attr,omitempty"`
+	Tags      string        `xml:"tags,attr,omitempty"`
+	Children  []opmlOutline `xml:"outline"`
+}
+
+func readOPML(data []byte) ([]Entry, error) {
+	var doc opmlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	var walk func([]opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			if streamURL := fallback(outline.URL, outline.LowerURL); streamURL != "" {
+				entries = append(entries, Entry{
+					Name: fallback(outline.Text, outline.Title),
+					URL:  streamURL,
+					UUID: outline.StationID,
+					Tags: outline.Tags,
+				})
+			}
+			walk(outline.Children)
+		}
+	}
+	walk(doc.Body)
+	return entries, nil
+}
+
+func writeOPML(w io.Writer, entries []Entry) error {
+	doc := opmlDocument{Version: "2.0", Title: "ValveFM favorites"}
+	for _, entry := range entries {
+		doc.Body = append(doc.Body, opmlOutline{
+			Text:      entry.Name,
+			Type:      "audio",
+			URL:       entry.URL,
+			StationID: entry.UUID,
+			Tags:      entry.Tags,
+		})
+	}
+	return writeXML(w, doc)
+}
+
+type xspfDocument struct {
+	XMLName xml.Name    `xml:"playlist"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title,omitempty"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string `xml:"location"`
+	Title      string `xml:"title,omitempty"`
+	Identifier string `xml:"identifier,omitempty"`
+	Info       string `xml:"info,omitempty"`
+}
+
+func readXSPF(data []byte) ([]Entry, error) {
+	var doc xspfDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(doc.Tracks))
+	for _, track := range doc.Tracks {
+		entries = append(entries, Entry{
+			Name:     track.Title,
+			URL:      track.Location,
+			UUID:     strings.TrimPrefix(track.Identifier, "urn:radiobrowser:"),
+			Homepage: track.Info,
+		})
+	}
+	return entries, nil
+}
+
+func writeXSPF(w io.Writer, entries []Entry) error {
+	doc := xspfDocument{Version: "1", XMLNS: "http://xspf.org/ns/0/", Title: "ValveFM favorites"}
+	for _, entry := range entries {
+		track := xspfTrack{Location: entry.URL, Title: entry.Name, Info: entry.Homepage}
+		if entry.UUID != "" {
+			track.Identifier = "urn:radiobrowser:" + entry.UUID
+		}
+		doc.Tracks = append(doc.Tracks, track)
+	}
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// jsonEntry reads Radio Browser station records, ValveFM exports and the
+// simple {name, url} lists other players write.
+type jsonEntry struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	URL         string `json:"url"`
+	URLResolved string `json:"url_resolved,omitempty"`
+	Stream      string `json:"stream,omitempty"`
+	StationUUID string `json:"stationuuid,omitempty"`
+	UUID        string `json:"uuid,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	Tags        string `json:"tags,omitempty"`
+	CountryCode string `json:"countrycode,omitempty"`
+	// Station is the nested record of a ValveFM favorites.json.
+	Station *jsonEntry `json:"station,omitempty"`
+}
+
+func readJSON(data []byte) ([]Entry, error) {
+	var items []jsonEntry
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var wrapped struct {
+			Stations []jsonEntry `json:"stations"`
+		}
+		if err := json.Unmarshal(data, &wrapped); err != nil {
+			return nil, err
+		}
+		items = wrapped.Stations
+	} else if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(items))
+	for _, item := range items {
+		entry := Entry{
+			Name:        fallback(item.Name, item.Title),
+			URL:         fallback(item.URLResolved, item.URL, item.Stream),
+			UUID:        fallback(item.StationUUID, item.UUID),
+			Homepage:    item.Homepage,
+			Tags:        item.Tags,
+			CountryCode: item.CountryCode,
+		}
+		if nested := item.Station; nested != nil {
+			entry.URL = fallback(entry.URL, nested.URLResolved, nested.URL)
+			entry.Homepage = fallback(entry.Homepage, nested.Homepage)
+			entry.CountryCode = fallback(entry.CountryCode, nested.CountryCode)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func writeJSON(w io.Writer, entries []Entry) error {
+	items := make([]jsonEntry, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, jsonEntry{
+			Name:        entry.Name,
+			URL:         entry.URL,
+			StationUUID: entry.UUID,
+			Homepage:    entry.Homepage,
+			Tags:        entry.Tags,
+			CountryCode: entry.CountryCode,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(items)
+}
+
+// oneLine keeps a title from breaking line-based formats.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func fallback(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
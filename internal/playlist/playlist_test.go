@@ -0,0 +1,142 @@
+package playlist
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	entries := []Entry{
+		{Name: "Jazz FM", URL: "http://jazz.example.com/live.mp3", UUID: "uuid-1", Tags: "jazz,smooth"},
+		{Name: "News & Talk", URL: "https://news.example.com/stream?x=1&y=2"},
+	}
+	for _, format := range Formats {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, format, entries); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if detected := Detect(buf.Bytes()); detected != format {
+				t.Errorf("Detect() = %s, want %s", detected, format)
+			}
+			got, err := Read(&buf, format)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if len(got) != len(entries) {
+				t.Fatalf("Read() = %+v", got)
+			}
+			for i, entry := range got {
+				if entry.Name != entries[i].Name || entry.URL != entries[i].URL {
+					t.Errorf("entry %d = %+v, want %+v", i, entry, entries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRead_ForeignPlaylists(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []Entry
+	}{
+		{
+			name:   "m3u with attributes and bare urls",
+			format: M3U,
+			input:  "#EXTM3U\r\n#EXTINF:-1 tvg-logo=\"x.png\",Radio One\r\nhttp://one.example.com/\r\n\r\n# comment\r\nhttp://two.example.com/\r\n",
+			want: []Entry{
+				{Name: "Radio One", URL: "http://one.example.com/"},
+				{Name: "http://two.example.com/", URL: "http://two.example.com/"},
+			},
+		},
+		{
+			name:   "pls out of order with mixed case keys",
+			format: PLS,
+			input:  "[playlist]\nfile2=http://two.example.com/\nFile1=http://one.example.com/\nTitle1=One\nNumberOfEntries=2\n",
+			want: []Entry{
+				{Name: "One", URL: "http://one.example.com/"},
+				{Name: "http://two.example.com/", URL: "http://two.example.com/"},
+			},
+		},
+		{
+			name:   "tunein opml with nested outlines",
+			format: OPML,
+			input: `<?xml version="1.0"?><opml version="1"><head><title>Presets</title></head><body>
+				<outline text="Music"><outline type="audio" text="Jazz" URL="http://jazz.example.com/" /></outline>
+				<outline type="link" text="More" />
+				<outline type="audio" title="Rock" url="http://rock.example.com/" />
+			</body></opml>`,
+			want: []Entry{
+				{Name: "Jazz", URL: "http://jazz.example.com/"},
+				{Name: "Rock", URL: "http://rock.example.com/"},
+			},
+		},
+		{
+			name:   "vlc xspf",
+			format: XSPF,
+			input: `<?xml version="1.0" encoding="UTF-8"?><playlist xmlns="http://xspf.org/ns/0/" version="1"><trackList>
+				<track><location>http://one.example.com/</location><title>One</title></track>
+			</trackList></playlist>`,
+			want: []Entry{{Name: "One", URL: "http://one.example.com/"}},
+		},
+		{
+			name:   "radio browser station records",
+			format: JSON,
+			input:  `[{"stationuuid":"uuid-1","name":"One","url":"http://one.example.com/pls","url_resolved":"http://one.example.com/live","countrycode":"DE"}]`,
+			want:   []Entry{{Name: "One", URL: "http://one.example.com/live", UUID: "uuid-1", CountryCode: "DE"}},
+		},
+		{
+			name:   "valvefm favorites file",
+			format: JSON,
+			input:  `{"stations":[{"uuid":"uuid-1","name":"One","station":{"url":"http://one.example.com/"}},{"uuid":"uuid-2","name":"No record"}]}`,
+			want:   []Entry{{Name: "One", URL: "http://one.example.com/", UUID: "uuid-1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input), "")
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %+v, want %+v", got, tt.want)
+			}
+			if detected := Detect([]byte(tt.input)); detected != tt.format {
+				t.Errorf("Detect() = %s, want %s", detected, tt.format)
+			}
+		})
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	if _, err := Read(strings.NewReader("<opml><body>"), OPML); err == nil {
+		t.Error("Read() should fail on broken XML")
+	}
+	if _, err := Read(strings.NewReader("[]"), "wav"); err == nil {
+		t.Error("Read() should reject an unknown format")
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]Format{
+		"favs.m3u":      M3U,
+		"favs.M3U8":     M3U,
+		"/tmp/x.pls":    PLS,
+		"radio.opml":    OPML,
+		"vlc.xspf":      XSPF,
+		"export.json":   JSON,
+		"favorites":     "",
+		"favorites.wav": "",
+	}
+	for path, want := range tests {
+		got, err := FormatFromPath(path)
+		if got != want || (want == "") != (err != nil) {
+			t.Errorf("FormatFromPath(%q) = %q, %v; want %q", path, got, err, want)
+		}
+	}
+}
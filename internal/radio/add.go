@@ -0,0 +1,115 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NewStation describes a station to submit to Radio Browser.
+type NewStation struct {
+	Name        string
+	URL         string
+	Homepage    string
+	Favicon     string
+	CountryCode string
+	State       string
+	Language    string
+	Tags        []string
+	Geo         *Point
+}
+
+// Validate checks the fields the API requires and the ones it would reject.
+func (s NewStation) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.New("station name is required")
+	}
+	if err := checkHTTPURL(s.URL); err != nil {
+		return fmt.Errorf("stream url: %w", err)
+	}
+	if strings.TrimSpace(s.Homepage) != "" {
+		if err := checkHTTPURL(s.Homepage); err != nil {
+			return fmt.Errorf("homepage: %w", err)
+		}
+	}
+	if strings.TrimSpace(s.Favicon) != "" {
+		if err := checkHTTPURL(s.Favicon); err != nil {
+			return fmt.Errorf("favicon: %w", err)
+		}
+	}
+	if code := strings.TrimSpace(s.CountryCode); code != "" && len(code) != 2 {
+		return fmt.Errorf("country code %q must have two letters", code)
+	}
+	if s.Geo != nil && !s.Geo.Valid() {
+		return errors.New("position is out of range")
+	}
+	return nil
+}
+
+// Values encodes the station as the /json/add form.
+func (s NewStation) Values() url.Values {
+	form := url.Values{}
+	form.Set("name", strings.TrimSpace(s.Name))
+	form.Set("url", strings.TrimSpace(s.URL))
+	setIfPresent(form, "homepage", s.Homepage)
+	setIfPresent(form, "favicon", s.Favicon)
+	setIfPresent(form, "countrycode", strings.ToUpper(strings.TrimSpace(s.CountryCode)))
+	setIfPresent(form, "state", s.State)
+	setIfPresent(form, "language", strings.ToLower(strings.TrimSpace(s.Language)))
+	if tags := cleanTags(s.Tags); len(tags) > 0 {
+		form.Set("tags", strings.Join(tags, ","))
+	}
+	if s.Geo != nil {
+		form.Set("geo_lat", strconv.FormatFloat(s.Geo.Lat, 'f', -1, 64))
+		form.Set("geo_long", strconv.FormatFloat(s.Geo.Long, 'f', -1, 64))
+	}
+	return form
+}
+
+type addResponse struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+	UUID    string `json:"uuid"`
+}
+
+// AddStation submits a station via /json/add and returns its new UUID.
+func (c *Client) AddStation(ctx context.Context, station NewStation) (string, error) {
+	if err := station.Validate(); err != nil {
+		return "", err
+	}
+
+	data, err := c.post(ctx, "/json/add", station.Values())
+	if err != nil {
+		return "", err
+	}
+	var result addResponse
+	if err := decodeJSON("/json/add", data, &result); err != nil {
+		return "", err
+	}
+	if !result.OK {
+		return "", fmt.Errorf("station rejected: %s", fallbackMessage(result.Message, "unknown reason"))
+	}
+	return result.UUID, nil
+}
+
+// post sends a form to the healthiest mirror. Unlike GETs it is never
+// retried on another mirror, so a station cannot be submitted twice.
+func (c *Client) post(ctx context.Context, path string, form url.Values) ([]byte, error) {
+	return c.once(ctx, http.MethodPost, path, strings.NewReader(form.Encode()), "application/x-www-form-urlencoded")
+}
+
+func checkHTTPURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return errors.New("is required")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", raw)
+	}
+	return nil
+}
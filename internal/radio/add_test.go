@@ -0,0 +1,91 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewStation_Validate(t *testing.T) {
+	valid := NewStation{Name: "Local FM", URL: "http://stream.example.com/live.mp3"}
+	tests := []struct {
+		name    string
+		edit    func(*NewStation)
+		wantErr string
+	}{
+		{"valid", func(*NewStation) {}, ""},
+		{"missing name", func(s *NewStation) { s.Name = " " }, "name is required"},
+		{"missing url", func(s *NewStation) { s.URL = "" }, "stream url"},
+		{"bad scheme", func(s *NewStation) { s.URL = "ftp://example.com/live" }, "stream url"},
+		{"bad homepage", func(s *NewStation) { s.Homepage = "example.com" }, "homepage"},
+		{"bad country", func(s *NewStation) { s.CountryCode = "DEU" }, "two letters"},
+		{"bad position", func(s *NewStation) { s.Geo = &Point{Lat: 95} }, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			station := valid
+			tt.edit(&station)
+			err := station.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_AddStation(t *testing.T) {
+	var (
+		method string
+		form   url.Values
+	)
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/add" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		method = r.Method
+		r.ParseForm()
+		form = r.PostForm
+		if form.Get("name") == "Dupe FM" {
+			w.Write([]byte(`{"ok":false,"message":"station already exists"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"message":"added station successfully","uuid":"new-uuid"}`))
+	})
+
+	station := NewStation{
+		Name:        "Local FM",
+		URL:         "http://stream.example.com/live.mp3",
+		Homepage:    "https://local.example.com",
+		CountryCode: "de",
+		Language:    "German",
+		Tags:        []string{"Pop", " news "},
+	}
+	uuid, err := client.AddStation(context.Background(), station)
+	if err != nil {
+		t.Fatalf("AddStation() error = %v", err)
+	}
+	if uuid != "new-uuid" || method != http.MethodPost {
+		t.Errorf("uuid = %q, method = %s", uuid, method)
+	}
+	if form.Get("countrycode") != "DE" || form.Get("tags") != "pop,news" || form.Get("language") != "german" || form.Get("homepage") != "https://local.example.com" {
+		t.Errorf("form = %v", form)
+	}
+
+	station.Name = "Dupe FM"
+	if _, err := client.AddStation(context.Background(), station); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("AddStation() error = %v, want the server's rejection", err)
+	}
+
+	station.URL = ""
+	if _, err := client.AddStation(context.Background(), station); err == nil {
+		t.Error("AddStation() should validate before submitting")
+	}
+}
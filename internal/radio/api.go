@@ -1,14 +1,16 @@
 package radio
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -17,13 +19,78 @@ import (
 const (
 	defaultBaseURL = "https://all.api.radio-browser.info"
 	requestTimeout = 12 * time.Second
-	maxPageSize    = 500
+	// MaxPageSize is the largest limit one list request may ask for.
+	MaxPageSize = 500
+	// maxResponseSize caps how much of a response body is read.
+	maxResponseSize = 10 << 20
+
+	// defaultMaxAttempts bounds how many mirrors one request is tried on.
+	defaultMaxAttempts  = 3
+	defaultRetryBackoff = 250 * time.Millisecond
 )
 
 type Client struct {
 	baseURL   string
 	userAgent string
 	http      *http.Client
+	cache     *Cache
+	headers   http.Header
+	resolver  Resolver
+	limiter   *rateLimiter
+	flight    flightGroup
+
+	// servers holds every known mirror; when nil, requests go to baseURL only.
+	servers      *serverPool
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// Option configures optional Client behaviour.
+type Option func(*Client)
+
+// WithCache stores responses in cache for revalidation and offline use.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithServers pins the client to the given mirrors (e.g. a self-hosted
+// radio-browser instance) instead of discovering them from /json/servers.
+func WithServers(baseURLs ...string) Option {
+	return func(c *Client) {
+		c.servers = newServerPool(baseURLs)
+	}
+}
+
+// WithResolver replaces the DNS resolver used for SRV server discovery.
+func WithResolver(resolver Resolver) Option {
+	return func(c *Client) {
+		c.resolver = resolver
+	}
+}
+
+// WithHeaders adds headers, such as Authorization, to every API request.
+// They are only accepted together with WithServers, so credentials never
+// reach the public mirrors.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		for name, value := range headers {
+			c.headers.Set(name, value)
+		}
+	}
+}
+
+// WithRetry sets how many mirrors a failing request is tried on and the
+// initial backoff between attempts, which doubles after each retry.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.retryBackoff = backoff
+	}
 }
 
 type serverInfo struct {
@@ -31,25 +98,50 @@ type serverInfo struct {
 }
 
 // NewClient creates a Radio Browser API client.
-func NewClient(userAgent string) (*Client, error) {
+func NewClient(userAgent string, opts ...Option) (*Client, error) {
 	if strings.TrimSpace(userAgent) == "" {
 		return nil, errors.New("user agent is required")
 	}
 
 	client := &Client{
-		baseURL:   defaultBaseURL,
-		userAgent: userAgent,
-		http:      &http.Client{Timeout: requestTimeout},
+		baseURL:      defaultBaseURL,
+		userAgent:    userAgent,
+		http:         &http.Client{Timeout: requestTimeout},
+		resolver:     net.DefaultResolver,
+		limiter:      newRateLimiter(defaultRateLimit, defaultRateBurst),
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
 
-	baseURL, err := client.pickRandomServer()
-	if err == nil && baseURL != "" {
-		client.baseURL = baseURL
+	if client.servers != nil && len(client.servers.servers) == 0 {
+		return nil, errors.New("no api servers configured")
+	}
+	if len(client.headers) > 0 && client.servers == nil {
+		return nil, errors.New("api headers require fixed api servers")
+	}
+
+	if client.servers == nil {
+		servers, err := client.discoverServers()
+		if err != nil || len(servers) == 0 {
+			servers = []string{client.baseURL}
+		}
+		client.servers = newServerPool(servers)
 	}
 
 	return client, nil
 }
 
+// ServerHealth reports the latency and failure history of each known mirror.
+func (c *Client) ServerHealth() []ServerHealth {
+	if c.servers == nil {
+		return []ServerHealth{{BaseURL: c.baseURL}}
+	}
+	return c.servers.snapshot()
+}
+
 // StationsByCountry fetches stations by ISO country code.
 func (c *Client) StationsByCountry(ctx context.Context, countryCode string) ([]Station, error) {
 	return c.StationsByCountryPage(ctx, countryCode, 200, 0)
@@ -70,9 +162,8 @@ func (c *Client) StationsByCountryPage(ctx context.Context, countryCode string,
 	endpoint := fmt.Sprintf("/json/stations/bycountrycodeexact/%s", url.PathEscape(countryCode))
 	query := stationQuery(limit, offset)
 
-	reqURL := c.baseURL + endpoint + "?" + query.Encode()
 	var stations []Station
-	if err := c.doJSON(ctx, reqURL, &stations); err != nil {
+	if err := c.doJSON(ctx, endpoint+"?"+query.Encode(), &stations); err != nil {
 		return nil, err
 	}
 	return stations, nil
@@ -113,9 +204,8 @@ func (c *Client) SearchStationsByCountry(ctx context.Context, countryCode string
 
 // Countries fetches available countries from the API.
 func (c *Client) Countries(ctx context.Context) ([]Country, error) {
-	reqURL := c.baseURL + "/json/countries"
 	var countries []Country
-	if err := c.doJSON(ctx, reqURL, &countries); err != nil {
+	if err := c.doJSON(ctx, "/json/countries", &countries); err != nil {
 		return nil, err
 	}
 
@@ -125,17 +215,27 @@ func (c *Client) Countries(ctx context.Context) ([]Country, error) {
 	return countries, nil
 }
 
-// ResolveStationURL calls /json/url/{stationuuid} and returns a resolved stream URL.
+// ResolveStationURL is CountClick under its original name.
 func (c *Client) ResolveStationURL(ctx context.Context, uuid string) (string, error) {
+	return c.CountClick(ctx, uuid)
+}
+
+// CountClick calls /json/url/{stationuuid}, which records a click for the
+// station as the API etiquette asks when playback starts, and returns the
+// resolved stream URL.
+func (c *Client) CountClick(ctx context.Context, uuid string) (string, error) {
 	uuid = strings.TrimSpace(uuid)
 	if uuid == "" {
 		return "", errors.New("station uuid is required")
 	}
+	if IsCustomUUID(uuid) {
+		return "", errCustomStation
+	}
 
 	endpoint := fmt.Sprintf("/json/url/%s", url.PathEscape(uuid))
-	reqURL := c.baseURL + endpoint
 
-	data, err := c.getBytes(ctx, reqURL)
+	// The endpoint answers with one station object or a list of them.
+	data, err := c.getOnce(ctx, endpoint)
 	if err != nil {
 		return "", err
 	}
@@ -146,15 +246,131 @@ func (c *Client) ResolveStationURL(ctx context.Context, uuid string) (string, er
 	}
 
 	var stations []Station
-	if err := json.Unmarshal(data, &stations); err != nil {
+	if err := decodeJSON(endpoint, data, &stations); err != nil {
 		return "", err
 	}
 	if len(stations) == 0 {
-		return "", errors.New("no station data returned")
+		return "", &NotFoundError{UUID: uuid}
 	}
 	return resolvedURL(stations[0])
 }
 
+// StationURL looks up a station's stream URL without counting a click.
+func (c *Client) StationURL(ctx context.Context, uuid string) (string, error) {
+	uuid = strings.TrimSpace(uuid)
+	if uuid == "" {
+		return "", errors.New("station uuid is required")
+	}
+	if IsCustomUUID(uuid) {
+		return "", errCustomStation
+	}
+
+	var stations []Station
+	endpoint := fmt.Sprintf("/json/stations/byuuid/%s", url.PathEscape(uuid))
+	if err := c.doJSON(ctx, endpoint, &stations); err != nil {
+		return "", err
+	}
+	if len(stations) == 0 {
+		return "", &NotFoundError{UUID: uuid}
+	}
+	return resolvedURL(stations[0])
+}
+
+// uuidBatchSize bounds how many UUIDs go into one byuuid request so the
+// URL stays well below common length limits.
+const uuidBatchSize = 100
+
+// StationsByUUIDs looks up stations in batches via /json/stations/byuuid.
+// Stations the server no longer knows are simply absent from the result,
+// which is not in request order. Custom station IDs are skipped.
+func (c *Client) StationsByUUIDs(ctx context.Context, uuids []string) ([]Station, error) {
+	seen := make(map[string]bool, len(uuids))
+	cleaned := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		uuid = strings.TrimSpace(uuid)
+		if uuid != "" && !IsCustomUUID(uuid) && !seen[uuid] {
+			seen[uuid] = true
+			cleaned = append(cleaned, uuid)
+		}
+	}
+
+	stations := make([]Station, 0, len(cleaned))
+	for start := 0; start < len(cleaned); start += uuidBatchSize {
+		end := min(start+uuidBatchSize, len(cleaned))
+		query := url.Values{}
+		query.Set("uuids", strings.Join(cleaned[start:end], ","))
+
+		var batch []Station
+		if err := c.doJSON(ctx, "/json/stations/byuuid?"+query.Encode(), &batch); err != nil {
+			return nil, err
+		}
+		stations = append(stations, batch...)
+	}
+	return stations, nil
+}
+
+// StationsByURL finds the stations whose stream URL is streamURL via
+// /json/stations/byurl. Most URLs match at most one station.
+func (c *Client) StationsByURL(ctx context.Context, streamURL string) ([]Station, error) {
+	streamURL = strings.TrimSpace(streamURL)
+	if streamURL == "" {
+		return nil, errors.New("stream url is required")
+	}
+	query := url.Values{}
+	query.Set("url", streamURL)
+
+	var stations []Station
+	if err := c.doJSON(ctx, "/json/stations/byurl?"+query.Encode(), &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+type voteResponse struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message"`
+}
+
+// Vote casts a vote for a station via /json/vote/{stationuuid}. The server
+// accepts one vote per station and client address every few minutes and
+// reports a rejected vote as an error.
+func (c *Client) Vote(ctx context.Context, uuid string) error {
+	uuid = strings.TrimSpace(uuid)
+	if uuid == "" {
+		return errors.New("station uuid is required")
+	}
+	if IsCustomUUID(uuid) {
+		return errCustomStation
+	}
+
+	endpoint := fmt.Sprintf("/json/vote/%s", url.PathEscape(uuid))
+	data, err := c.getOnce(ctx, endpoint)
+	if err != nil {
+		return uncertainError(err)
+	}
+	var result voteResponse
+	if err := decodeJSON(endpoint, data, &result); err != nil {
+		return err
+	}
+	if !result.OK {
+		return fmt.Errorf("vote rejected: %s", fallbackMessage(result.Message, "unknown reason"))
+	}
+	return nil
+}
+
+// StreamURL returns the stream URL already known for station, preferring
+// the resolved URL.
+func StreamURL(station Station) (string, error) {
+	return resolvedURL(station)
+}
+
+func fallbackMessage(message string, fallback string) string {
+	if strings.TrimSpace(message) == "" {
+		return fallback
+	}
+	return message
+}
+
 func resolvedURL(station Station) (string, error) {
 	if strings.TrimSpace(station.URLResolved) != "" {
 		return station.URLResolved, nil
@@ -165,57 +381,316 @@ func resolvedURL(station Station) (string, error) {
 	return "", errors.New("station has no stream url")
 }
 
-func (c *Client) doJSON(ctx context.Context, reqURL string, target any) error {
-	data, err := c.getBytes(ctx, reqURL)
+// doJSON fetches an API path (with query) and decodes the JSON response
+// while it downloads. Concurrent callers asking for the same path and type
+// share one request and its decoded value.
+func (c *Client) doJSON(ctx context.Context, path string, target any) error {
+	targetType := reflect.TypeOf(target).Elem()
+	value, info, err := c.flight.do(ctx, flightKey(path, target), func(ctx context.Context) (any, *ResponseInfo, error) {
+		ctx, info := WithResponseInfo(ctx)
+		value := reflect.New(targetType).Interface()
+		err := c.load(ctx, path, value)
+		return value, info, err
+	})
+	if info != nil && !info.FetchedAt().IsZero() {
+		recordResponse(ctx, info.Cached(), info.Stale(), info.FetchedAt())
+	}
+	var timeout *TimeoutError
+	if errors.Is(err, context.DeadlineExceeded) && !errors.As(err, &timeout) {
+		// The caller's own deadline ran out, e.g. while waiting for a slot.
+		err = &TimeoutError{URL: path, Err: err}
+	}
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(data, target)
+
+	shared := reflect.ValueOf(value).Elem()
+	if shared.Kind() == reflect.Slice && !shared.IsNil() {
+		// Callers may sort or edit their results; give each its own slice.
+		own := reflect.MakeSlice(shared.Type(), shared.Len(), shared.Len())
+		reflect.Copy(own, shared)
+		shared = own
+	}
+	reflect.ValueOf(target).Elem().Set(shared)
+	return nil
+}
+
+// flightKey identifies a request for coalescing; the target type is part of
+// it because followers receive the decoded value.
+func flightKey(path string, target any) string {
+	return fmt.Sprintf("%T %s", target, path)
 }
 
-func (c *Client) getBytes(ctx context.Context, reqURL string) ([]byte, error) {
+// load decodes path into target, serving it from the cache when fresh
+// enough. The body is only kept in memory when it is going to be cached.
+func (c *Client) load(ctx context.Context, path string, target any) error {
+	decode := func(body io.Reader) error {
+		return decodeStream(path, body, target)
+	}
+	key, ttl := "", time.Duration(0)
+	if c.cache != nil {
+		key, ttl = cacheKey(path)
+	}
+	if key == "" {
+		_, _, err := c.fetch(ctx, path, cacheEntry{}, decode, false)
+		return err
+	}
+
+	entry, hit := c.cache.get(key)
+	if hit && c.cache.now().Sub(entry.FetchedAt) < ttl {
+		recordResponse(ctx, true, false, entry.FetchedAt)
+		return decodeJSON(path, entry.Body, target)
+	}
+
+	data, resp, err := c.fetch(ctx, path, entry, decode, true)
+	if err != nil {
+		// Serve stale data when the server cannot be reached, but not when the
+		// caller gave up or the server answered with a client error.
+		if hit && ctx.Err() == nil && retryable(resp, err) {
+			recordResponse(ctx, true, true, entry.FetchedAt)
+			return decodeJSON(path, entry.Body, target)
+		}
+		return err
+	}
+
+	now := c.cache.now()
+	if resp.StatusCode == http.StatusNotModified && hit {
+		entry.FetchedAt = now
+		_ = c.cache.put(entry)
+		recordResponse(ctx, false, false, now)
+		return decodeJSON(path, entry.Body, target)
+	}
+
+	_ = c.cache.put(cacheEntry{
+		Key:          key,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    now,
+		Body:         data,
+	})
+	recordResponse(ctx, false, false, now)
+	return nil
+}
+
+// fetch GETs path from the healthiest mirror, moving on to the next one with
+// exponential backoff when a server is unreachable or answers with a 5xx.
+// A 429 is retried on the same server after its Retry-After delay.
+// The last response is returned (with its body consumed) whenever a server
+// answered; the body itself is only returned when keep is set.
+func (c *Client) fetch(ctx context.Context, path string, validators cacheEntry, decode func(io.Reader) error, keep bool) ([]byte, *http.Response, error) {
+	servers := []string{c.baseURL}
+	if c.servers != nil {
+		servers = c.servers.ordered()
+	}
+	attempts := c.maxAttempts
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+	attempts = min(attempts, len(servers))
+
+	var (
+		data []byte
+		resp *http.Response
+		err  error
+	)
+	backoff := c.retryBackoff
+	var wait time.Duration
+	throttled := 0
+	for i := 0; i < attempts; {
+		if wait > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, resp, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, resp, err
+		}
+
+		baseURL := servers[i]
+		start := time.Now()
+		data, resp, err = c.fetchFrom(ctx, baseURL+path, validators, decode, keep)
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests && throttled < maxThrottledRetries && ctx.Err() == nil {
+			wait = retryAfter(resp, time.Now(), backoff)
+			if wait > maxRetryAfter {
+				return data, resp, err
+			}
+			// Hold back every request, not just this one, until the server is ready.
+			c.limiter.pause(wait)
+			throttled++
+			continue
+		}
+		if !retryable(resp, err) {
+			if c.servers != nil {
+				c.servers.recordSuccess(baseURL, time.Since(start))
+			}
+			return data, resp, err
+		}
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the server.
+			return data, resp, err
+		}
+		if c.servers != nil {
+			c.servers.recordFailure(baseURL)
+		}
+		i++
+		wait = backoff
+		backoff *= 2
+	}
+	return data, resp, err
+}
+
+// getOnce performs a GET that has side effects on the server, such as a
+// vote or a click. Like post it bypasses the cache and request coalescing
+// and is sent to one mirror only, so it is counted at most once.
+func (c *Client) getOnce(ctx context.Context, path string) ([]byte, error) {
+	return c.once(ctx, http.MethodGet, path, nil, "")
+}
+
+// once sends a single request to the healthiest mirror without failing
+// over to another one.
+func (c *Client) once(ctx context.Context, method string, path string, body io.Reader, contentType string) ([]byte, error) {
+	baseURL := c.baseURL
+	if c.servers != nil {
+		if servers := c.servers.ordered(); len(servers) > 0 {
+			baseURL = servers[0]
+		}
+	}
+	if err := c.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", c.userAgent)
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, err
+		if c.servers != nil {
+			c.servers.recordFailure(baseURL)
+		}
+		return nil, transportError(baseURL+path, err)
 	}
 	defer resp.Body.Close()
-
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("request failed: %s", resp.Status)
+		return nil, statusError(baseURL+path, resp)
 	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return nil, bodyError(baseURL+path, err)
+	}
+	return data, nil
+}
 
-	// Limit response size to 10MB to prevent OOM on malformed responses
-	return io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024))
+// retryable reports whether a request should be tried on another mirror.
+// Client errors (4xx) are the same everywhere, so only transport failures
+// and server errors qualify.
+func retryable(resp *http.Response, err error) bool {
+	if err == nil {
+		return false
+	}
+	return resp == nil || resp.StatusCode >= 500
 }
 
-func (c *Client) pickRandomServer() (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+// fetchFrom performs a single GET, sending validators from a previous cache
+// entry when present. The body is streamed through decode; it is buffered
+// and returned as well only when keep is set, e.g. for the cache.
+func (c *Client) fetchFrom(ctx context.Context, reqURL string, validators cacheEntry, decode func(io.Reader) error, keep bool) ([]byte, *http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	reqURL := defaultBaseURL + "/json/servers"
-	var servers []serverInfo
-	if err := c.doJSON(ctx, reqURL, &servers); err != nil {
-		return "", err
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, nil, transportError(reqURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && (validators.ETag != "" || validators.LastModified != "") {
+		return nil, resp, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, resp, statusError(reqURL, resp)
+	}
+
+	// Limit response size to prevent OOM on malformed responses.
+	body := &cappedReader{r: resp.Body, remaining: maxResponseSize}
+	var reader io.Reader = body
+	var data *bytes.Buffer
+	if keep {
+		data = &bytes.Buffer{}
+		reader = io.TeeReader(body, data)
 	}
-	if len(servers) == 0 {
-		return "", errors.New("no api servers returned")
+	if decode != nil {
+		if err := decode(reader); err != nil {
+			if body.err != nil {
+				return nil, resp, bodyError(reqURL, body.err)
+			}
+			return nil, resp, err
+		}
 	}
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return nil, resp, bodyError(reqURL, err)
+	}
+	if data == nil {
+		return nil, resp, nil
+	}
+	return data.Bytes(), resp, nil
+}
 
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	choice := servers[r.Intn(len(servers))].Name
-	if strings.TrimSpace(choice) == "" {
-		return "", errors.New("empty server name")
+// errResponseTooLarge is reported for bodies over maxResponseSize.
+var errResponseTooLarge = fmt.Errorf("response larger than %d MB", maxResponseSize>>20)
+
+// cappedReader reads at most remaining bytes and fails, rather than
+// silently truncating, when the body is longer.
+type cappedReader struct {
+	r         io.Reader
+	remaining int64
+	err       error
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if int64(len(p)) > c.remaining+1 {
+		p = p[:c.remaining+1]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	if c.remaining < 0 {
+		c.err = errResponseTooLarge
+		return n + int(c.remaining), c.err
+	}
+	if err != nil && err != io.EOF {
+		c.err = err
 	}
-	return "https://" + choice, nil
+	return n, err
 }
 
 func stationQuery(limit int, offset int) url.Values {
@@ -232,8 +707,8 @@ func sanitizePage(limit int, offset int) (int, int, error) {
 	if limit <= 0 {
 		return 0, 0, errors.New("limit must be greater than zero")
 	}
-	if limit > maxPageSize {
-		return 0, 0, fmt.Errorf("limit must be <= %d", maxPageSize)
+	if limit > MaxPageSize {
+		return 0, 0, fmt.Errorf("limit must be <= %d", MaxPageSize)
 	}
 	if offset < 0 {
 		return 0, 0, errors.New("offset must be >= 0")
@@ -246,9 +721,8 @@ func (c *Client) searchStationsByCountry(ctx context.Context, countryCode string
 	query.Set("countrycodeexact", countryCode)
 	query.Set(field, value)
 
-	reqURL := c.baseURL + "/json/stations/search?" + query.Encode()
 	var stations []Station
-	if err := c.doJSON(ctx, reqURL, &stations); err != nil {
+	if err := c.doJSON(ctx, "/json/stations/search?"+query.Encode(), &stations); err != nil {
 		return nil, err
 	}
 	return stations, nil
@@ -3,6 +3,9 @@ package radio
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -387,6 +390,154 @@ func TestClient_ResolveStationURL(t *testing.T) {
 	}
 }
 
+func TestClient_StationURL_DoesNotCountClick(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/stations/byuuid/test-uuid" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode([]Station{{UUID: "test-uuid", URL: "http://stream.example.com"}})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:   server.URL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}
+
+	url, err := client.StationURL(context.Background(), "test-uuid")
+	if err != nil {
+		t.Fatalf("StationURL() error = %v", err)
+	}
+	if url != "http://stream.example.com" {
+		t.Errorf("StationURL() = %q", url)
+	}
+}
+
+func TestClient_StationsByUUIDs_Batches(t *testing.T) {
+	var batches [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/stations/byuuid" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		uuids := strings.Split(r.URL.Query().Get("uuids"), ",")
+		batches = append(batches, uuids)
+		// The server omits unknown stations.
+		var stations []Station
+		for _, uuid := range uuids {
+			if uuid != "gone" {
+				stations = append(stations, Station{UUID: uuid})
+			}
+		}
+		json.NewEncoder(w).Encode(stations)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:   server.URL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}
+
+	uuids := []string{"gone", " ", "gone"}
+	for i := 0; i < uuidBatchSize+5; i++ {
+		uuids = append(uuids, fmt.Sprintf("uuid-%d", i))
+	}
+	stations, err := client.StationsByUUIDs(context.Background(), uuids)
+	if err != nil {
+		t.Fatalf("StationsByUUIDs() error = %v", err)
+	}
+	if len(batches) != 2 || len(batches[0]) != uuidBatchSize || len(batches[1]) != 6 {
+		t.Errorf("batch sizes = %d, want %d and 6", len(batches), uuidBatchSize)
+	}
+	if len(stations) != uuidBatchSize+5 {
+		t.Errorf("StationsByUUIDs() returned %d stations, want %d", len(stations), uuidBatchSize+5)
+	}
+
+	stations, err = client.StationsByUUIDs(context.Background(), nil)
+	if err != nil || len(stations) != 0 || len(batches) != 2 {
+		t.Errorf("empty lookup should not call the API: %v, %v", stations, err)
+	}
+}
+
+func TestClient_StationsByURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/stations/byurl" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("url"); got != "http://example.com/live?a=1&b=2" {
+			t.Errorf("url = %q", got)
+		}
+		w.Write([]byte(`[{"stationuuid":"found","url":"http://example.com/live?a=1&b=2"}]`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:   server.URL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}
+
+	stations, err := client.StationsByURL(context.Background(), " http://example.com/live?a=1&b=2 ")
+	if err != nil {
+		t.Fatalf("StationsByURL() error = %v", err)
+	}
+	if len(stations) != 1 || stations[0].UUID != "found" {
+		t.Errorf("StationsByURL() = %+v", stations)
+	}
+	if _, err := client.StationsByURL(context.Background(), " "); err == nil {
+		t.Error("StationsByURL() with an empty url should fail")
+	}
+}
+
+func TestClient_Vote(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{"accepted", `{"ok":true,"message":"voted for station successfully"}`, ""},
+		{"too often", `{"ok":false,"message":"you are voting for the same station too often"}`, "too often"},
+		{"no message", `{"ok":false}`, "unknown reason"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/json/vote/test-uuid" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				baseURL:   server.URL,
+				userAgent: "TestApp/1.0",
+				http:      &http.Client{Timeout: 5 * time.Second},
+			}
+
+			err := client.Vote(context.Background(), "test-uuid")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Vote() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Vote() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_Vote_EmptyUUID(t *testing.T) {
+	client := &Client{baseURL: "http://example.com", userAgent: "TestApp/1.0", http: http.DefaultClient}
+	if err := client.Vote(context.Background(), " "); err == nil {
+		t.Error("Vote() should require a station uuid")
+	}
+}
+
 func TestClient_ResolveStationURL_EmptyUUID(t *testing.T) {
 	client := &Client{
 		baseURL:   "http://example.com",
@@ -518,3 +669,60 @@ func TestResolvedURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCappedReader(t *testing.T) {
+	exact := &cappedReader{r: strings.NewReader("abcd"), remaining: 4}
+	if data, err := io.ReadAll(exact); err != nil || string(data) != "abcd" {
+		t.Errorf("ReadAll(exact) = %q, %v", data, err)
+	}
+
+	long := &cappedReader{r: strings.NewReader("abcdef"), remaining: 4}
+	data, err := io.ReadAll(long)
+	if !errors.Is(err, errResponseTooLarge) || string(data) != "abcd" {
+		t.Errorf("ReadAll(long) = %q, %v; want the first 4 bytes and errResponseTooLarge", data, err)
+	}
+}
+
+func TestClient_DecodesStreamedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"Germany","iso_3166_1":"DE","stationcount":10}]`))
+		w.(http.Flusher).Flush()
+		w.Write([]byte("\n"))
+	}))
+	defer server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL), WithCache(NewCache(t.TempDir())))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		countries, err := client.Countries(context.Background())
+		if err != nil || len(countries) != 1 || countries[0].Code != "DE" {
+			t.Fatalf("Countries() #%d = %+v, %v", i+1, countries, err)
+		}
+	}
+}
+
+func TestClient_FetchFrom_BuffersOnlyWhenKept(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name":"Germany","iso_3166_1":"DE"}]`))
+	}))
+	defer server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	for _, keep := range []bool{false, true} {
+		var countries []Country
+		decode := func(body io.Reader) error { return decodeStream("/json/countries", body, &countries) }
+		data, _, err := client.fetchFrom(context.Background(), server.URL+"/json/countries", cacheEntry{}, decode, keep)
+		if err != nil || len(countries) != 1 {
+			t.Fatalf("fetchFrom(keep=%v) = %+v, %v", keep, countries, err)
+		}
+		if (data != nil) != keep {
+			t.Errorf("fetchFrom(keep=%v) returned %d body bytes", keep, len(data))
+		}
+	}
+}
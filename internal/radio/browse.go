@@ -0,0 +1,123 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Tag is a station tag (genre) with the number of stations using it.
+type Tag struct {
+	Name         string `json:"name"`
+	StationCount int    `json:"stationcount"`
+}
+
+// Language is a broadcast language with its station count.
+type Language struct {
+	Name         string `json:"name"`
+	Code         string `json:"iso_639"`
+	StationCount int    `json:"stationcount"`
+}
+
+// State is a region within a country.
+type State struct {
+	Name         string `json:"name"`
+	Country      string `json:"country"`
+	StationCount int    `json:"stationcount"`
+}
+
+// Tags lists tags containing filter (all tags when empty), most used first.
+func (c *Client) Tags(ctx context.Context, filter string, limit int) ([]Tag, error) {
+	endpoint := "/json/tags"
+	if filter = strings.TrimSpace(filter); filter != "" {
+		endpoint += "/" + url.PathEscape(filter)
+	}
+	query, err := listQuery(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	if err := c.doJSON(ctx, endpoint+"?"+query.Encode(), &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Languages lists broadcast languages, most used first.
+func (c *Client) Languages(ctx context.Context) ([]Language, error) {
+	query, err := listQuery(MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var languages []Language
+	if err := c.doJSON(ctx, "/json/languages?"+query.Encode(), &languages); err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
+
+// States lists states for a country name (e.g. "Germany"), or for all
+// countries when country is empty.
+func (c *Client) States(ctx context.Context, country string) ([]State, error) {
+	endpoint := "/json/states"
+	if country = strings.TrimSpace(country); country != "" {
+		endpoint += "/" + url.PathEscape(country) + "/"
+	}
+	query, err := listQuery(MaxPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []State
+	if err := c.doJSON(ctx, endpoint+"?"+query.Encode(), &states); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// StationsByTag fetches stations worldwide with exactly this tag, with pagination.
+func (c *Client) StationsByTag(ctx context.Context, tag string, limit int, offset int) ([]Station, error) {
+	return c.stationsBy(ctx, "bytagexact", strings.ToLower(strings.TrimSpace(tag)), "tag", limit, offset)
+}
+
+// StationsByLanguage fetches stations worldwide broadcasting in language, with pagination.
+func (c *Client) StationsByLanguage(ctx context.Context, language string, limit int, offset int) ([]Station, error) {
+	return c.stationsBy(ctx, "bylanguageexact", strings.ToLower(strings.TrimSpace(language)), "language", limit, offset)
+}
+
+func (c *Client) stationsBy(ctx context.Context, by string, value string, what string, limit int, offset int) ([]Station, error) {
+	if value == "" {
+		return nil, fmt.Errorf("%s is required", what)
+	}
+
+	limit, offset, err := sanitizePage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/json/stations/%s/%s", by, url.PathEscape(value))
+	query := stationQuery(limit, offset)
+
+	var stations []Station
+	if err := c.doJSON(ctx, endpoint+"?"+query.Encode(), &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+// listQuery orders tag/language/state lists by station count, skipping broken stations.
+func listQuery(limit int) (url.Values, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+	query := url.Values{}
+	query.Set("hidebroken", "true")
+	query.Set("order", "stationcount")
+	query.Set("reverse", "true")
+	query.Set("limit", fmt.Sprintf("%d", limit))
+	return query, nil
+}
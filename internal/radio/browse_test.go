@@ -0,0 +1,104 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newBrowseTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{
+		baseURL:   server.URL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func TestClient_Tags(t *testing.T) {
+	var path, order, limit string
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, order, limit = r.URL.EscapedPath(), r.URL.Query().Get("order"), r.URL.Query().Get("limit")
+		json.NewEncoder(w).Encode([]Tag{{Name: "jazz", StationCount: 1200}})
+	})
+
+	tags, err := client.Tags(context.Background(), "smooth jazz", 100)
+	if err != nil {
+		t.Fatalf("Tags() error = %v", err)
+	}
+	if path != "/json/tags/smooth%20jazz" || order != "stationcount" || limit != "100" {
+		t.Errorf("path/order/limit = %q/%q/%q", path, order, limit)
+	}
+	if len(tags) != 1 || tags[0].Name != "jazz" || tags[0].StationCount != 1200 {
+		t.Errorf("Tags() = %+v", tags)
+	}
+
+	if _, err := client.Tags(context.Background(), "", 0); err == nil {
+		t.Error("Tags() should require a positive limit")
+	}
+}
+
+func TestClient_LanguagesAndStates(t *testing.T) {
+	var paths []string
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/json/languages":
+			w.Write([]byte(`[{"name":"mongolian","iso_639":"mn","stationcount":12}]`))
+		default:
+			w.Write([]byte(`[{"name":"Bavaria","country":"Germany","stationcount":300}]`))
+		}
+	})
+
+	languages, err := client.Languages(context.Background())
+	if err != nil {
+		t.Fatalf("Languages() error = %v", err)
+	}
+	if len(languages) != 1 || languages[0].Code != "mn" {
+		t.Errorf("Languages() = %+v", languages)
+	}
+
+	states, err := client.States(context.Background(), "Germany")
+	if err != nil {
+		t.Fatalf("States() error = %v", err)
+	}
+	if len(states) != 1 || states[0].Country != "Germany" {
+		t.Errorf("States() = %+v", states)
+	}
+	if paths[1] != "/json/states/Germany/" {
+		t.Errorf("States path = %q", paths[1])
+	}
+}
+
+func TestClient_StationsByTagAndLanguage(t *testing.T) {
+	var paths []string
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Query().Get("offset") != "20" || r.URL.Query().Get("hidebroken") != "true" {
+			t.Errorf("query = %v", r.URL.Query())
+		}
+		json.NewEncoder(w).Encode([]Station{{UUID: "a"}})
+	})
+
+	if _, err := client.StationsByTag(context.Background(), " Jazz ", 10, 20); err != nil {
+		t.Fatalf("StationsByTag() error = %v", err)
+	}
+	if _, err := client.StationsByLanguage(context.Background(), "Mongolian", 10, 20); err != nil {
+		t.Fatalf("StationsByLanguage() error = %v", err)
+	}
+	if paths[0] != "/json/stations/bytagexact/jazz" || paths[1] != "/json/stations/bylanguageexact/mongolian" {
+		t.Errorf("paths = %v", paths)
+	}
+
+	if _, err := client.StationsByTag(context.Background(), " ", 10, 0); err == nil {
+		t.Error("StationsByTag() should require a tag")
+	}
+	if _, err := client.StationsByLanguage(context.Background(), "x", 0, 0); err == nil {
+		t.Error("StationsByLanguage() should validate paging")
+	}
+}
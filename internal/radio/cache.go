@@ -0,0 +1,219 @@
+package radio
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTLs maps API path prefixes to how long a cached response is served
+// without contacting the server. Paths without an entry are never cached.
+var cacheTTLs = []struct {
+	prefix string
+	ttl    time.Duration
+}{
+	{"/json/countries", 24 * time.Hour},
+	{"/json/tags", 24 * time.Hour},
+	{"/json/languages", 24 * time.Hour},
+	{"/json/states", 24 * time.Hour},
+	{"/json/stations/lastclick", time.Minute},
+	{"/json/stations/lastchange", time.Minute},
+	{"/json/stations/", 10 * time.Minute},
+}
+
+const (
+	// maxCacheAge is how long an entry is kept after it was last written;
+	// older data is not worth showing even offline.
+	maxCacheAge = 30 * 24 * time.Hour
+	// defaultMaxCacheEntries bounds the number of cached responses, since
+	// every distinct search adds one.
+	defaultMaxCacheEntries = 500
+)
+
+// Cache stores API responses on disk so they can be revalidated cheaply and
+// served when the network is unavailable.
+type Cache struct {
+	mu         sync.Mutex
+	dir        string
+	now        func() time.Time
+	maxEntries int
+}
+
+type cacheEntry struct {
+	Key          string    `json:"key"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	Body         []byte    `json:"body"`
+}
+
+// NewCache returns a cache rooted at dir. The directory is created on first write.
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now, maxEntries: defaultMaxCacheEntries}
+}
+
+// DefaultCacheDir returns ~/.cache/valvefm/api (or the platform equivalent).
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "valvefm", "api"), nil
+}
+
+func (c *Cache) get(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *Cache) put(entry cacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated entry.
+	path := c.path(entry.Key)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+	c.prune()
+	return nil
+}
+
+// prune removes entries written more than maxCacheAge ago and then the
+// oldest ones beyond maxEntries. Failures are ignored; the next write tries
+// again.
+func (c *Cache) prune() {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type cachedFile struct {
+		path    string
+		written time.Time
+	}
+	cutoff := time.Now().Add(-maxCacheAge)
+	var kept []cachedFile
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(c.dir, file.Name())
+		if info.ModTime().Before(cutoff) {
+			_ = os.Remove(path)
+			continue
+		}
+		kept = append(kept, cachedFile{path: path, written: info.ModTime()})
+	}
+	if len(kept) <= c.maxEntries {
+		return
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].written.After(kept[j].written) })
+	for _, file := range kept[c.maxEntries:] {
+		_ = os.Remove(file.path)
+	}
+}
+
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cacheKey identifies a request path independently of which mirror served it.
+func cacheKey(path string) (string, time.Duration) {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return "", 0
+	}
+	for _, rule := range cacheTTLs {
+		if strings.HasPrefix(parsed.Path, rule.prefix) {
+			return parsed.RequestURI(), rule.ttl
+		}
+	}
+	return "", 0
+}
+
+// ResponseInfo reports where the data returned by Client calls came from.
+type ResponseInfo struct {
+	mu        sync.Mutex
+	cached    bool
+	stale     bool
+	fetchedAt time.Time
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a context that collects ResponseInfo for calls made with it.
+func WithResponseInfo(ctx context.Context) (context.Context, *ResponseInfo) {
+	info := &ResponseInfo{}
+	return context.WithValue(ctx, responseInfoKey{}, info), info
+}
+
+// Cached reports whether data came from the on-disk cache without a fresh
+// response from the server.
+func (r *ResponseInfo) Cached() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cached
+}
+
+// Stale reports whether the cache was used because the server was unreachable.
+func (r *ResponseInfo) Stale() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stale
+}
+
+// FetchedAt returns when the oldest returned data was last confirmed by the server.
+func (r *ResponseInfo) FetchedAt() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fetchedAt
+}
+
+func recordResponse(ctx context.Context, cached bool, stale bool, fetchedAt time.Time) {
+	info, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	if !ok {
+		return
+	}
+	info.mu.Lock()
+	defer info.mu.Unlock()
+	info.cached = info.cached || cached
+	info.stale = info.stale || stale
+	if info.fetchedAt.IsZero() || fetchedAt.Before(info.fetchedAt) {
+		info.fetchedAt = fetchedAt
+	}
+}
@@ -0,0 +1,242 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCachedTestClient returns a client whose cache clock can be advanced.
+func newCachedTestClient(t *testing.T, baseURL string) (*Client, *time.Time) {
+	t.Helper()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCache(t.TempDir())
+	cache.now = func() time.Time { return now }
+	return &Client{
+		baseURL:   baseURL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+		cache:     cache,
+	}, &now
+}
+
+func TestClient_Cache_FreshHit(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode([]Country{{Code: "US", Name: "United States"}})
+	}))
+	defer server.Close()
+
+	client, _ := newCachedTestClient(t, server.URL)
+	if _, err := client.Countries(context.Background()); err != nil {
+		t.Fatalf("Countries() error = %v", err)
+	}
+
+	ctx, info := WithResponseInfo(context.Background())
+	result, err := client.Countries(ctx)
+	if err != nil {
+		t.Fatalf("Countries() error = %v", err)
+	}
+	if len(result) != 1 || result[0].Code != "US" {
+		t.Errorf("cached result = %+v", result)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("requests = %d, want 1 (second call served from cache)", requests.Load())
+	}
+	if !info.Cached() || info.Stale() {
+		t.Errorf("info cached/stale = %v/%v, want true/false", info.Cached(), info.Stale())
+	}
+}
+
+func TestClient_Cache_RevalidatesWithETag(t *testing.T) {
+	var requests atomic.Int32
+	var gotIfNoneMatch atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if inm := r.Header.Get("If-None-Match"); inm != "" {
+			gotIfNoneMatch.Store(inm)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode([]Station{{UUID: "a", Name: "Alpha"}})
+	}))
+	defer server.Close()
+
+	client, now := newCachedTestClient(t, server.URL)
+	if _, err := client.StationsByCountryPage(context.Background(), "US", 10, 0); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+
+	*now = now.Add(time.Hour)
+	ctx, info := WithResponseInfo(context.Background())
+	result, err := client.StationsByCountryPage(ctx, "US", 10, 0)
+	if err != nil {
+		t.Fatalf("revalidated call error = %v", err)
+	}
+	if len(result) != 1 || result[0].UUID != "a" {
+		t.Errorf("revalidated result = %+v", result)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2", requests.Load())
+	}
+	if got, _ := gotIfNoneMatch.Load().(string); got != `"v1"` {
+		t.Errorf("If-None-Match = %q, want %q", got, `"v1"`)
+	}
+	if info.Cached() {
+		t.Error("a 304 confirms the data, so it should not be reported as cached")
+	}
+}
+
+func TestClient_Cache_StaleWhenOffline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		json.NewEncoder(w).Encode([]Station{{UUID: "a", Name: "Alpha"}})
+	}))
+
+	client, now := newCachedTestClient(t, server.URL)
+	if _, err := client.SearchStationsByCountry(context.Background(), "US", "alpha", 10, 0); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	server.Close()
+
+	*now = now.Add(48 * time.Hour)
+	ctx, info := WithResponseInfo(context.Background())
+	result, err := client.SearchStationsByCountry(ctx, "US", "alpha", 10, 0)
+	if err != nil {
+		t.Fatalf("offline call error = %v", err)
+	}
+	if len(result) != 1 || result[0].UUID != "a" {
+		t.Errorf("stale result = %+v", result)
+	}
+	if !info.Cached() || !info.Stale() {
+		t.Errorf("info cached/stale = %v/%v, want true/true", info.Cached(), info.Stale())
+	}
+	if want := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC); !info.FetchedAt().Equal(want) {
+		t.Errorf("FetchedAt() = %v, want %v", info.FetchedAt(), want)
+	}
+}
+
+func TestClient_Cache_ServerErrors(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if code := int(status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		json.NewEncoder(w).Encode([]Station{{UUID: "a"}})
+	}))
+	defer server.Close()
+
+	client, now := newCachedTestClient(t, server.URL)
+	if _, err := client.StationsByCountry(context.Background(), "US"); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	*now = now.Add(time.Hour)
+
+	status.Store(http.StatusServiceUnavailable)
+	if _, err := client.StationsByCountry(context.Background(), "US"); err != nil {
+		t.Errorf("5xx should fall back to stale data, got %v", err)
+	}
+
+	status.Store(http.StatusNotFound)
+	if _, err := client.StationsByCountry(context.Background(), "US"); err == nil {
+		t.Error("4xx should not be masked by stale data")
+	}
+}
+
+func TestClient_Cache_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Station{{UUID: "a"}})
+	}))
+	defer server.Close()
+
+	client, now := newCachedTestClient(t, server.URL)
+	if _, err := client.StationsByCountry(context.Background(), "US"); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	*now = now.Add(time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.StationsByCountry(ctx, "US"); err == nil {
+		t.Error("cancelled request should not be answered from the stale cache")
+	}
+}
+
+func TestClient_Cache_SkipsResolveURL(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		json.NewEncoder(w).Encode(Station{UUID: "a", URLResolved: "http://stream"})
+	}))
+	defer server.Close()
+
+	client, _ := newCachedTestClient(t, server.URL)
+	for i := 0; i < 2; i++ {
+		if _, err := client.ResolveStationURL(context.Background(), "a"); err != nil {
+			t.Fatalf("ResolveStationURL() error = %v", err)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Errorf("requests = %d, want 2 (click counting must not be cached)", requests.Load())
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantKey string
+		cached  bool
+	}{
+		{"https://a.example/json/countries", "/json/countries", true},
+		{"https://b.example/json/stations/search?name=x", "/json/stations/search?name=x", true},
+		{"https://a.example/json/url/abc", "", false},
+		{"https://a.example/json/servers", "", false},
+	}
+	for _, tt := range tests {
+		key, ttl := cacheKey(tt.url)
+		if key != tt.wantKey || (ttl > 0) != tt.cached {
+			t.Errorf("cacheKey(%q) = %q/%v, want %q cached=%v", tt.url, key, ttl, tt.wantKey, tt.cached)
+		}
+	}
+}
+
+func TestCache_Prune(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	cache.maxEntries = 2
+	put := func(key string, written time.Time) {
+		t.Helper()
+		if err := cache.put(cacheEntry{Key: key, Body: []byte("[]")}); err != nil {
+			t.Fatalf("put(%s) error = %v", key, err)
+		}
+		if err := os.Chtimes(cache.path(key), written, written); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	put("/json/tags", now.Add(-maxCacheAge-time.Hour))
+	put("/json/countries", now.Add(-2*time.Hour))
+	if _, ok := cache.get("/json/tags"); ok {
+		t.Error("an entry older than maxCacheAge should be pruned")
+	}
+
+	put("/json/languages", now.Add(-time.Hour))
+	put("/json/states/Germany/", now)
+	if _, ok := cache.get("/json/countries"); ok {
+		t.Error("the oldest entry beyond maxEntries should be pruned")
+	}
+	for _, key := range []string{"/json/languages", "/json/states/Germany/"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("get(%s) should find the newer entry", key)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package radio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Chart is a ranking of stations offered by Radio Browser.
+type Chart string
+
+const (
+	ChartTopClick   Chart = "topclick"
+	ChartTopVote    Chart = "topvote"
+	ChartTrending   Chart = "trending"
+	ChartLastClick  Chart = "lastclick"
+	ChartLastChange Chart = "lastchange"
+)
+
+// Charts lists every chart in display order.
+var Charts = []Chart{ChartTopClick, ChartTopVote, ChartTrending, ChartLastClick, ChartLastChange}
+
+// Title is a human readable chart name.
+func (c Chart) Title() string {
+	switch c {
+	case ChartTopClick:
+		return "Most played"
+	case ChartTopVote:
+		return "Most voted"
+	case ChartTrending:
+		return "Trending"
+	case ChartLastClick:
+		return "Recently played"
+	case ChartLastChange:
+		return "Recently changed"
+	}
+	return string(c)
+}
+
+// SearchOrder is the SearchParams order equivalent to the chart, used to
+// scope it to a country or combine it with other filters.
+func (c Chart) SearchOrder() string {
+	switch c {
+	case ChartTopClick:
+		return "clickcount"
+	case ChartTopVote:
+		return "votes"
+	case ChartTrending:
+		return "clicktrend"
+	case ChartLastClick:
+		return "clicktimestamp"
+	case ChartLastChange:
+		return "changetimestamp"
+	}
+	return ""
+}
+
+// TopClick fetches the most played stations worldwide.
+func (c *Client) TopClick(ctx context.Context, limit int, offset int) ([]Station, error) {
+	return c.chartStations(ctx, "/json/stations/topclick", limit, offset)
+}
+
+// TopVote fetches the most voted stations worldwide.
+func (c *Client) TopVote(ctx context.Context, limit int, offset int) ([]Station, error) {
+	return c.chartStations(ctx, "/json/stations/topvote", limit, offset)
+}
+
+// LastClick fetches the most recently played stations worldwide.
+func (c *Client) LastClick(ctx context.Context, limit int, offset int) ([]Station, error) {
+	return c.chartStations(ctx, "/json/stations/lastclick", limit, offset)
+}
+
+// LastChange fetches the most recently added or edited stations worldwide.
+func (c *Client) LastChange(ctx context.Context, limit int, offset int) ([]Station, error) {
+	return c.chartStations(ctx, "/json/stations/lastchange", limit, offset)
+}
+
+// Trending fetches the stations with the highest click trend worldwide.
+func (c *Client) Trending(ctx context.Context, limit int, offset int) ([]Station, error) {
+	return c.Search(ctx, SearchParams{Order: "clicktrend", Limit: limit, Offset: offset})
+}
+
+// ChartStations fetches a page of chart, worldwide when countryCode is empty
+// or restricted to that country otherwise.
+func (c *Client) ChartStations(ctx context.Context, chart Chart, countryCode string, limit int, offset int) ([]Station, error) {
+	order := chart.SearchOrder()
+	if order == "" {
+		return nil, fmt.Errorf("unknown chart %q", chart)
+	}
+
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+	if countryCode != "" {
+		return c.Search(ctx, SearchParams{CountryCode: countryCode, Order: order, Limit: limit, Offset: offset})
+	}
+
+	switch chart {
+	case ChartTopClick:
+		return c.TopClick(ctx, limit, offset)
+	case ChartTopVote:
+		return c.TopVote(ctx, limit, offset)
+	case ChartLastClick:
+		return c.LastClick(ctx, limit, offset)
+	case ChartLastChange:
+		return c.LastChange(ctx, limit, offset)
+	}
+	return c.Trending(ctx, limit, offset)
+}
+
+func (c *Client) chartStations(ctx context.Context, endpoint string, limit int, offset int) ([]Station, error) {
+	limit, offset, err := sanitizePage(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	query := stationQuery(limit, offset)
+	// The chart endpoints define their own ordering.
+	query.Del("order")
+	query.Del("reverse")
+
+	var stations []Station
+	if err := c.doJSON(ctx, endpoint+"?"+query.Encode(), &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
@@ -0,0 +1,59 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_ChartStations(t *testing.T) {
+	var (
+		path  string
+		query url.Values
+	)
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`[{"stationuuid":"a","name":"Chart FM"}]`))
+	})
+
+	tests := []struct {
+		chart     Chart
+		country   string
+		wantPath  string
+		wantOrder string
+	}{
+		{ChartTopClick, "", "/json/stations/topclick", ""},
+		{ChartTopVote, "", "/json/stations/topvote", ""},
+		{ChartLastClick, "", "/json/stations/lastclick", ""},
+		{ChartLastChange, "", "/json/stations/lastchange", ""},
+		{ChartTrending, "", "/json/stations/search", "clicktrend"},
+		{ChartTopVote, "de", "/json/stations/search", "votes"},
+		{ChartLastClick, "DE", "/json/stations/search", "clicktimestamp"},
+	}
+	for _, tt := range tests {
+		stations, err := client.ChartStations(context.Background(), tt.chart, tt.country, 50, 100)
+		if err != nil {
+			t.Fatalf("ChartStations(%s, %q) error = %v", tt.chart, tt.country, err)
+		}
+		if len(stations) != 1 || stations[0].Name != "Chart FM" {
+			t.Errorf("ChartStations(%s) = %+v", tt.chart, stations)
+		}
+		if path != tt.wantPath || query.Get("order") != tt.wantOrder {
+			t.Errorf("ChartStations(%s, %q) path/order = %q/%q, want %q/%q", tt.chart, tt.country, path, query.Get("order"), tt.wantPath, tt.wantOrder)
+		}
+		if query.Get("limit") != "50" || query.Get("offset") != "100" || query.Get("hidebroken") != "true" {
+			t.Errorf("ChartStations(%s) query = %v", tt.chart, query)
+		}
+		if tt.country != "" && query.Get("countrycodeexact") != "DE" {
+			t.Errorf("ChartStations(%s, %q) should scope to the country, query = %v", tt.chart, tt.country, query)
+		}
+	}
+
+	if _, err := client.ChartStations(context.Background(), Chart("bogus"), "", 10, 0); err == nil {
+		t.Error("ChartStations() should reject an unknown chart")
+	}
+	if _, err := client.TopClick(context.Background(), 0, 0); err == nil {
+		t.Error("TopClick() should validate the page size")
+	}
+}
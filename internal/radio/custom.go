@@ -0,0 +1,58 @@
+package radio
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// customPrefix starts the IDs of stations defined locally rather than in
+// Radio Browser. Directory UUIDs never carry it.
+const customPrefix = "custom-"
+
+// errCustomStation is returned by API calls made for a locally defined station.
+var errCustomStation = errors.New("custom stations are not listed on Radio Browser")
+
+// IsCustomUUID reports whether uuid belongs to a locally defined station.
+func IsCustomUUID(uuid string) bool {
+	return strings.HasPrefix(uuid, customPrefix)
+}
+
+// Custom reports whether the station was defined locally, in which case it
+// is played from its own URL and never looked up on Radio Browser.
+func (s Station) Custom() bool {
+	return IsCustomUUID(s.UUID)
+}
+
+// CustomStation turns s into a locally defined station with a fresh ID.
+// Only the name and stream URL are required.
+func (s NewStation) CustomStation() (Station, error) {
+	if err := s.Validate(); err != nil {
+		return Station{}, err
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return Station{}, err
+	}
+	streamURL := strings.TrimSpace(s.URL)
+	countryCode := strings.ToUpper(strings.TrimSpace(s.CountryCode))
+	station := Station{
+		UUID:        customPrefix + hex.EncodeToString(id[:]),
+		Name:        strings.TrimSpace(s.Name),
+		Country:     countryCode,
+		CountryCode: countryCode,
+		State:       strings.TrimSpace(s.State),
+		Language:    strings.ToLower(strings.TrimSpace(s.Language)),
+		Tags:        strings.Join(cleanTags(s.Tags), ","),
+		URL:         streamURL,
+		URLResolved: streamURL,
+		Homepage:    strings.TrimSpace(s.Homepage),
+		Favicon:     strings.TrimSpace(s.Favicon),
+	}
+	if s.Geo != nil {
+		station.GeoLat = Coordinate(s.Geo.Lat)
+		station.GeoLong = Coordinate(s.Geo.Long)
+	}
+	return station, nil
+}
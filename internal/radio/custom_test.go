@@ -0,0 +1,74 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewStation_CustomStation(t *testing.T) {
+	spec := NewStation{Name: " Office Radio ", URL: "http://intranet.example.com/live", CountryCode: "de", Tags: []string{"Internal", ""}}
+	first, err := spec.CustomStation()
+	if err != nil {
+		t.Fatalf("CustomStation() error = %v", err)
+	}
+	second, _ := spec.CustomStation()
+
+	if !first.Custom() || !strings.HasPrefix(first.UUID, customPrefix) || first.UUID == second.UUID {
+		t.Errorf("ids = %q, %q; want distinct custom ids", first.UUID, second.UUID)
+	}
+	if first.Name != "Office Radio" || first.CountryCode != "DE" || first.Tags != "internal" {
+		t.Errorf("CustomStation() = %+v", first)
+	}
+	if url, err := StreamURL(first); err != nil || url != "http://intranet.example.com/live" {
+		t.Errorf("StreamURL() = %q, %v", url, err)
+	}
+
+	if _, err := (NewStation{Name: "No URL"}).CustomStation(); err == nil {
+		t.Error("CustomStation() should require a stream URL")
+	}
+	if (Station{UUID: "96202f73-0601-11e8-ae97-52543be04c81"}).Custom() {
+		t.Error("directory stations are not custom")
+	}
+}
+
+func TestClient_SkipsCustomStations(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if uuids := r.URL.Query().Get("uuids"); strings.Contains(uuids, customPrefix) {
+			t.Errorf("custom id sent to the API: %q", uuids)
+		}
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+	custom := customPrefix + "0123456789abcdef"
+
+	if _, err := client.CountClick(ctx, custom); err != errCustomStation {
+		t.Errorf("CountClick() error = %v", err)
+	}
+	if _, err := client.StationURL(ctx, custom); err != errCustomStation {
+		t.Errorf("StationURL() error = %v", err)
+	}
+	if err := client.Vote(ctx, custom); err != errCustomStation {
+		t.Errorf("Vote() error = %v", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("custom stations should not reach the API, got %d calls", calls.Load())
+	}
+
+	if _, err := client.StationsByUUIDs(ctx, []string{custom, "real"}); err != nil {
+		t.Fatalf("StationsByUUIDs() error = %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("StationsByUUIDs() made %d calls, want 1", calls.Load())
+	}
+}
@@ -0,0 +1,106 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	srvService = "api"
+	srvProto   = "tcp"
+	srvDomain  = "radio-browser.info"
+
+	srvTimeout = 5 * time.Second
+)
+
+// Resolver looks up DNS SRV records. *net.Resolver satisfies it; tests can
+// substitute fake records.
+type Resolver interface {
+	LookupSRV(ctx context.Context, service string, proto string, name string) (string, []*net.SRV, error)
+}
+
+// discoverServers lists the API mirrors, preferring the documented
+// _api._tcp.radio-browser.info SRV record and falling back to the
+// /json/servers endpoint of baseURL.
+func (c *Client) discoverServers() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	servers, srvErr := c.lookupSRVServers(ctx)
+	if srvErr == nil {
+		return servers, nil
+	}
+	servers, err := c.fetchServerList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("server discovery failed: srv: %v; http: %w", srvErr, err)
+	}
+	return servers, nil
+}
+
+// lookupSRVServers returns mirrors in SRV priority order; the resolver already
+// shuffles records of equal priority by weight.
+func (c *Client) lookupSRVServers(ctx context.Context) ([]string, error) {
+	if c.resolver == nil {
+		return nil, errors.New("no resolver configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, srvTimeout)
+	defer cancel()
+
+	_, records, err := c.resolver.LookupSRV(ctx, srvService, srvProto, srvDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURLs := make([]string, 0, len(records))
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		host := strings.TrimSuffix(strings.TrimSpace(record.Target), ".")
+		if host == "" {
+			continue
+		}
+		if record.Port != 0 && record.Port != 443 {
+			host = net.JoinHostPort(host, fmt.Sprintf("%d", record.Port))
+		}
+		baseURLs = append(baseURLs, "https://"+host)
+	}
+	if len(baseURLs) == 0 {
+		return nil, errors.New("no srv records returned")
+	}
+	return baseURLs, nil
+}
+
+// fetchServerList lists all mirrors from /json/servers in random order so
+// clients spread their load until latency measurements take over.
+func (c *Client) fetchServerList(ctx context.Context) ([]string, error) {
+	var servers []serverInfo
+	if err := c.doJSON(ctx, "/json/servers", &servers); err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("no api servers returned")
+	}
+
+	baseURLs := make([]string, 0, len(servers))
+	for _, server := range servers {
+		name := strings.TrimSpace(server.Name)
+		if name == "" {
+			continue
+		}
+		baseURLs = append(baseURLs, "https://"+name)
+	}
+	if len(baseURLs) == 0 {
+		return nil, errors.New("empty server name")
+	}
+	rand.Shuffle(len(baseURLs), func(i, j int) {
+		baseURLs[i], baseURLs[j] = baseURLs[j], baseURLs[i]
+	})
+	return baseURLs, nil
+}
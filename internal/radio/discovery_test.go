@@ -0,0 +1,117 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type fakeResolver struct {
+	records []*net.SRV
+	err     error
+	calls   int
+}
+
+func (f *fakeResolver) LookupSRV(ctx context.Context, service string, proto string, name string) (string, []*net.SRV, error) {
+	f.calls++
+	if service != "api" || proto != "tcp" || name != "radio-browser.info" {
+		return "", nil, errors.New("unexpected srv query")
+	}
+	return "_api._tcp.radio-browser.info.", f.records, f.err
+}
+
+func TestDiscoverServers_SRV(t *testing.T) {
+	resolver := &fakeResolver{records: []*net.SRV{
+		{Target: "de1.api.radio-browser.info.", Port: 443, Priority: 1},
+		{Target: "nl1.api.radio-browser.info.", Port: 8443, Priority: 1},
+		{Target: "", Port: 443},
+	}}
+	client := &Client{baseURL: "http://127.0.0.1:1", userAgent: "TestApp/1.0", http: http.DefaultClient, resolver: resolver}
+
+	servers, err := client.discoverServers()
+	if err != nil {
+		t.Fatalf("discoverServers() error = %v", err)
+	}
+	want := []string{"https://de1.api.radio-browser.info", "https://nl1.api.radio-browser.info:8443"}
+	if len(servers) != len(want) {
+		t.Fatalf("discoverServers() = %v, want %v", servers, want)
+	}
+	for i := range want {
+		if servers[i] != want[i] {
+			t.Errorf("servers[%d] = %q, want %q", i, servers[i], want[i])
+		}
+	}
+}
+
+func TestDiscoverServers_FallsBackToHTTP(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/servers" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		requested = true
+		json.NewEncoder(w).Encode([]serverInfo{{Name: "at1.api.radio-browser.info"}, {Name: " "}})
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		resolver Resolver
+	}{
+		{"lookup error", &fakeResolver{err: errors.New("no such host")}},
+		{"no records", &fakeResolver{}},
+		{"no resolver", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = false
+			client := &Client{baseURL: server.URL, userAgent: "TestApp/1.0", http: server.Client(), resolver: tt.resolver}
+
+			servers, err := client.discoverServers()
+			if err != nil {
+				t.Fatalf("discoverServers() error = %v", err)
+			}
+			if !requested {
+				t.Error("expected /json/servers fallback request")
+			}
+			if len(servers) != 1 || servers[0] != "https://at1.api.radio-browser.info" {
+				t.Errorf("discoverServers() = %v", servers)
+			}
+		})
+	}
+}
+
+func TestNewClient_FallsBackToDefaultBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resolver := &fakeResolver{err: errors.New("no such host")}
+	client, err := NewClient("TestApp/1.0", WithResolver(resolver), func(c *Client) {
+		c.baseURL = server.URL
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if resolver.calls != 1 {
+		t.Errorf("resolver calls = %d, want 1", resolver.calls)
+	}
+	if health := client.ServerHealth(); len(health) != 1 || health[0].BaseURL != server.URL {
+		t.Errorf("ServerHealth() = %+v, want only the base URL", health)
+	}
+}
+
+func TestNewClient_FixedServersSkipSRV(t *testing.T) {
+	resolver := &fakeResolver{}
+	if _, err := NewClient("TestApp/1.0", WithResolver(resolver), WithServers("http://localhost:8080")); err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if resolver.calls != 0 {
+		t.Error("fixed servers should not trigger SRV discovery")
+	}
+}
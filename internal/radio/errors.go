@@ -0,0 +1,193 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NetworkError reports that no answer came back from the server, e.g. a
+// refused connection or a DNS failure.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("network error: %v", e.Err)
+}
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// TimeoutError reports that the server did not answer in time.
+type TimeoutError struct {
+	URL string
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("request timed out: %v", e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error { return e.Err }
+
+// StatusError reports a response with a non-2xx status code.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed: %s", fallbackMessage(e.Status, http.StatusText(e.StatusCode)))
+}
+
+// RateLimitError reports a 429 the client gave up waiting on. RetryAfter
+// is the delay the server asked for, or zero when it gave none.
+type RateLimitError struct {
+	StatusError
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter.Round(time.Second))
+	}
+	return "rate limited"
+}
+
+func (e *RateLimitError) Unwrap() error { return &e.StatusError }
+
+// DecodeError reports a response that was not the expected JSON.
+type DecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("invalid response from %s: %v", e.Path, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// NotFoundError reports that the API does not know a station.
+type NotFoundError struct {
+	UUID string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.UUID == "" {
+		return "station not found"
+	}
+	return fmt.Sprintf("station %s not found", e.UUID)
+}
+
+// UncertainError reports a failed request that may still have reached
+// the server, e.g. a vote whose answer was lost. Repeating it could count
+// it twice, so it is never transient.
+type UncertainError struct {
+	Err error
+}
+
+func (e *UncertainError) Error() string {
+	return fmt.Sprintf("%v (the request may still have been counted)", e.Err)
+}
+
+func (e *UncertainError) Unwrap() error { return e.Err }
+
+// IsTransient reports whether err may go away by itself, so the request is
+// worth retrying: network failures, timeouts, rate limiting and 5xx
+// answers. A cancelled request and an UncertainError are not transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var (
+		uncertain *UncertainError
+		network   *NetworkError
+		timeout   *TimeoutError
+		rateLimit *RateLimitError
+		status    *StatusError
+	)
+	switch {
+	case errors.As(err, &uncertain):
+		return false
+	case errors.As(err, &network), errors.As(err, &timeout), errors.As(err, &rateLimit):
+		return true
+	case errors.As(err, &status):
+		return status.StatusCode >= 500
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// transportError classifies an error from http.Client.Do. Cancellation by
+// the caller is returned unchanged.
+func transportError(reqURL string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	var netErr interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{URL: reqURL, Err: err}
+	}
+	return &NetworkError{URL: reqURL, Err: err}
+}
+
+// uncertainError wraps an error from a request with side effects in an
+// UncertainError unless it shows the server never acted on it: a failed
+// DNS lookup or connection, or a 4xx answer such as rate limiting.
+func uncertainError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+	var (
+		status *StatusError
+		dnsErr *net.DNSError
+		opErr  *net.OpError
+	)
+	switch {
+	case errors.As(err, &status) && status.StatusCode < 500,
+		errors.As(err, &dnsErr),
+		errors.As(err, &opErr) && opErr.Op == "dial":
+		return err
+	}
+	return &UncertainError{Err: err}
+}
+
+// bodyError classifies a failure while reading a response body.
+func bodyError(reqURL string, err error) error {
+	if errors.Is(err, errResponseTooLarge) {
+		return &DecodeError{Path: reqURL, Err: err}
+	}
+	return transportError(reqURL, err)
+}
+
+// statusError describes a non-2xx response.
+func statusError(reqURL string, resp *http.Response) error {
+	status := StatusError{URL: reqURL, StatusCode: resp.StatusCode, Status: resp.Status}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{StatusError: status, RetryAfter: retryAfter(resp, time.Now(), 0)}
+	}
+	return &status
+}
+
+// decodeStream decodes one JSON value from body into target.
+func decodeStream(path string, body io.Reader, target any) error {
+	if err := json.NewDecoder(body).Decode(target); err != nil {
+		return &DecodeError{Path: path, Err: err}
+	}
+	return nil
+}
+
+// decodeJSON unmarshals a response body from path into target.
+func decodeJSON(path string, data []byte, target any) error {
+	if err := json.Unmarshal(data, target); err != nil {
+		return &DecodeError{Path: path, Err: err}
+	}
+	return nil
+}
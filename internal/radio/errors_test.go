@@ -0,0 +1,110 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_TypedErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json/countries":
+			w.Write([]byte("<html>maintenance</html>"))
+		case "/json/stations/byuuid/gone":
+			w.Write([]byte("[]"))
+		case "/json/stations/byuuid/slow":
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte("[]"))
+		case "/json/vote/busy":
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/json/vote/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL), WithRetry(1, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	_, err = client.Countries(ctx)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || IsTransient(err) {
+		t.Errorf("Countries() error = %v, want a permanent DecodeError", err)
+	}
+
+	_, err = client.StationURL(ctx, "gone")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || notFound.UUID != "gone" || IsTransient(err) {
+		t.Errorf("StationURL(gone) error = %v, want NotFoundError", err)
+	}
+
+	err = client.Vote(ctx, "busy")
+	var (
+		rateLimited *RateLimitError
+		statusErr   *StatusError
+	)
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter != time.Hour || !IsTransient(err) {
+		t.Errorf("Vote(busy) error = %v, want RateLimitError after 1h", err)
+	}
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("a RateLimitError should also be a StatusError, got %v", err)
+	}
+
+	// The vote may have been counted before the server failed, so repeating
+	// it is not safe.
+	err = client.Vote(ctx, "down")
+	var uncertain *UncertainError
+	if !errors.As(err, &uncertain) || !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable || IsTransient(err) {
+		t.Errorf("Vote(down) error = %v, want an uncertain StatusError 503", err)
+	}
+
+	err = client.Vote(ctx, "unknown")
+	if errors.As(err, &uncertain) || !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || IsTransient(err) {
+		t.Errorf("Vote(unknown) error = %v, want a permanent StatusError 404", err)
+	}
+
+	shortCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = client.StationURL(shortCtx, "slow")
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) || !IsTransient(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("StationURL(slow) error = %v, want TimeoutError", err)
+	}
+}
+
+func TestClient_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL), WithRetry(1, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.Countries(context.Background())
+	var network *NetworkError
+	if !errors.As(err, &network) || !IsTransient(err) {
+		t.Errorf("Countries() error = %v, want a transient NetworkError", err)
+	}
+
+	// A refused connection never reached the server, so a vote can be repeated.
+	err = client.Vote(context.Background(), "a")
+	var uncertain *UncertainError
+	if !errors.As(err, &network) || errors.As(err, &uncertain) || !IsTransient(err) {
+		t.Errorf("Vote() error = %v, want a transient NetworkError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Countries(ctx); IsTransient(err) || !errors.Is(err, context.Canceled) {
+		t.Errorf("a cancelled request should not be transient, got %v", err)
+	}
+}
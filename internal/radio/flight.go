@@ -0,0 +1,68 @@
+package radio
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests so that only one of
+// them reaches the API; the others wait for and share its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	value   any
+	info    *ResponseInfo
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do runs fn once per key at a time. The shared call keeps running while any
+// caller still waits for it and is cancelled once they have all given up.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (any, *ResponseInfo, error)) (any, *ResponseInfo, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go func() {
+			call.value, call.info, call.err = fn(callCtx)
+			g.mu.Lock()
+			g.forget(key, call)
+			g.mu.Unlock()
+			cancel()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.info, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the result any more; later callers start afresh.
+			g.forget(key, call)
+			call.cancel()
+		}
+		g.mu.Unlock()
+		return nil, nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) forget(key string, call *flightCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,168 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_CoalescesConcurrentRequests(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`[{"name":"Germany","iso_3166_1":"DE"}]`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			countries, err := client.Countries(context.Background())
+			if err != nil {
+				t.Errorf("Countries() error = %v", err)
+			}
+			results[i] = len(countries)
+		}(i)
+	}
+
+	// Let every caller join the in-flight request before answering it.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		client.flight.mu.Lock()
+		waiters := 0
+		if call := client.flight.calls[flightKey("/json/countries", &[]Country{})]; call != nil {
+			waiters = call.waiters
+		}
+		client.flight.mu.Unlock()
+		if waiters == callers || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	release <- struct{}{}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("server calls = %d, want 1", calls.Load())
+	}
+	for i, n := range results {
+		if n != 1 {
+			t.Errorf("caller %d got %d countries, want 1", i, n)
+		}
+	}
+}
+
+func TestFlightGroup_Cancellation(t *testing.T) {
+	var group flightGroup
+	started := make(chan struct{})
+	sharedDone := make(chan error, 1)
+	fn := func(ctx context.Context) (any, *ResponseInfo, error) {
+		close(started)
+		<-ctx.Done()
+		sharedDone <- ctx.Err()
+		return nil, nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, _, err := group.do(first, "key", fn)
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, _, err := group.do(second, "key", fn)
+		errs <- err
+	}()
+	for {
+		group.mu.Lock()
+		waiters := group.calls["key"].waiters
+		group.mu.Unlock()
+		if waiters == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancelFirst()
+	if err := <-errs; err != context.Canceled {
+		t.Fatalf("first caller error = %v, want canceled", err)
+	}
+	select {
+	case <-sharedDone:
+		t.Fatal("the shared request should keep running while a caller waits")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancelSecond()
+	<-errs
+	select {
+	case <-sharedDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the shared request should be cancelled once every caller gave up")
+	}
+}
+
+func TestClient_SharedResultsAreIndependent(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`[{"name":"Germany","iso_3166_1":"DE"},{"name":"Austria","iso_3166_1":"AT"}]`))
+	}))
+	defer server.Close()
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	results := make(chan []Station, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			var stations []Station
+			if err := client.doJSON(context.Background(), "/json/stations", &stations); err != nil {
+				t.Errorf("doJSON() error = %v", err)
+			}
+			results <- stations
+		}()
+	}
+	for {
+		client.flight.mu.Lock()
+		call := client.flight.calls[flightKey("/json/stations", &[]Station{})]
+		waiters := 0
+		if call != nil {
+			waiters = call.waiters
+		}
+		client.flight.mu.Unlock()
+		if waiters == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	first, second := <-results, <-results
+	if len(first) != 2 || len(second) != 2 {
+		t.Fatalf("results = %+v, %+v", first, second)
+	}
+	first[0].Name = "Edited"
+	if second[0].Name == "Edited" {
+		t.Error("callers sharing a request should not share the result slice")
+	}
+}
@@ -0,0 +1,91 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultNearbyRadiusKm is the search radius used when none is given.
+	DefaultNearbyRadiusKm = 100
+	earthRadiusKm         = 6371.0
+)
+
+// Point is a position in decimal degrees.
+type Point struct {
+	Lat  float64 `json:"lat"`
+	Long float64 `json:"long"`
+}
+
+// Valid reports whether the point lies within latitude/longitude bounds.
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Long >= -180 && p.Long <= 180
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%.4f, %.4f", p.Lat, p.Long)
+}
+
+// ParsePoint reads "lat, long" (a comma and/or spaces separate the values).
+func ParsePoint(text string) (Point, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t'
+	})
+	if len(fields) != 2 {
+		return Point{}, fmt.Errorf("%q is not a \"lat, long\" position", strings.TrimSpace(text))
+	}
+	lat, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude %q", fields[0])
+	}
+	long, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude %q", fields[1])
+	}
+	point := Point{Lat: lat, Long: long}
+	if !point.Valid() {
+		return Point{}, fmt.Errorf("position %s is out of range", point)
+	}
+	return point, nil
+}
+
+// DistanceKm is the great-circle distance between two points.
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLong := (b.Long - a.Long) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Point returns the station's map position; check HasGeo first.
+func (s Station) Point() Point {
+	return Point{Lat: s.GeoLat.Float64(), Long: s.GeoLong.Float64()}
+}
+
+// StationsNear fetches up to limit stations within radiusKm of origin (the
+// default radius when radiusKm <= 0), nearest first.
+func (c *Client) StationsNear(ctx context.Context, origin Point, radiusKm float64, limit int) ([]Station, error) {
+	if !origin.Valid() {
+		return nil, errors.New("position is out of range")
+	}
+	if radiusKm <= 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	}
+
+	// The API filters by distance but cannot sort by it, so take the most
+	// popular stations in range and order them here.
+	stations, err := c.Search(ctx, SearchParams{Near: &origin, RadiusKm: radiusKm, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(stations, func(i, j int) bool {
+		return DistanceKm(origin, stations[i].Point()) < DistanceKm(origin, stations[j].Point())
+	})
+	return stations, nil
+}
@@ -0,0 +1,72 @@
+package radio
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestParsePoint(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    Point
+		wantErr bool
+	}{
+		{"52.52, 13.405", Point{Lat: 52.52, Long: 13.405}, false},
+		{" -33.87 151.21 ", Point{Lat: -33.87, Long: 151.21}, false},
+		{"52.52", Point{}, true},
+		{"north, 13", Point{}, true},
+		{"91, 0", Point{}, true},
+		{"0, 181", Point{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePoint(tt.text)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePoint(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePoint(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	berlin := Point{Lat: 52.52, Long: 13.405}
+	paris := Point{Lat: 48.8566, Long: 2.3522}
+	if got := DistanceKm(berlin, paris); math.Abs(got-878) > 5 {
+		t.Errorf("DistanceKm(Berlin, Paris) = %.1f, want about 878", got)
+	}
+	if got := DistanceKm(berlin, berlin); got != 0 {
+		t.Errorf("DistanceKm(same point) = %v, want 0", got)
+	}
+}
+
+func TestClient_StationsNear(t *testing.T) {
+	var query url.Values
+	client := newBrowseTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`[
+			{"stationuuid":"far","name":"Far FM","geo_lat":53.55,"geo_long":9.99},
+			{"stationuuid":"near","name":"Near FM","geo_lat":"52.50","geo_long":"13.40"}
+		]`))
+	})
+
+	origin := Point{Lat: 52.52, Long: 13.405}
+	stations, err := client.StationsNear(context.Background(), origin, 300, 50)
+	if err != nil {
+		t.Fatalf("StationsNear() error = %v", err)
+	}
+	if query.Get("geo_lat") != "52.52" || query.Get("geo_long") != "13.405" || query.Get("geo_distance") != "300000" || query.Get("has_geo_info") != "true" {
+		t.Errorf("query = %v", query)
+	}
+	if len(stations) != 2 || stations[0].UUID != "near" || stations[1].UUID != "far" {
+		t.Errorf("StationsNear() should sort by distance, got %+v", stations)
+	}
+
+	if _, err := client.StationsNear(context.Background(), Point{Lat: 100}, 0, 50); err == nil {
+		t.Error("StationsNear() should reject an invalid position")
+	}
+}
@@ -0,0 +1,126 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRateLimit and defaultRateBurst keep a burst of UI actions (such
+	// as holding the next page key) from flooding the shared API servers.
+	defaultRateLimit = 5
+	defaultRateBurst = 10
+
+	// maxRetryAfter is the longest Retry-After a request waits out; longer
+	// delays fail the request instead of freezing the caller.
+	maxRetryAfter = 30 * time.Second
+	// maxThrottledRetries bounds how often one request retries after a 429.
+	maxThrottledRetries = 2
+)
+
+// WithRateLimit caps outgoing API requests at perSecond with bursts of up to
+// burst requests. A rate of zero or less disables client-side limiting.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(perSecond, burst)
+	}
+}
+
+// rateLimiter is a token bucket shared by every request of a Client. A nil
+// limiter never blocks.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	burst = max(burst, 1)
+	return &rateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		delay := l.reserve()
+		if delay <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token when one is available, otherwise it reports how long
+// to wait before trying again.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// pause holds back every request for d, as asked by a server's Retry-After.
+func (l *rateLimiter) pause(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// retryAfter reads a Retry-After header given in seconds or as an HTTP date,
+// returning fallback when it is missing or malformed.
+func retryAfter(resp *http.Response, now time.Time, fallback time.Duration) time.Duration {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return fallback
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return max(when.Sub(now), 0)
+	}
+	return fallback
+}
@@ -0,0 +1,130 @@
+package radio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_TokenBucket(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2, 3)
+	limiter.last = now
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if delay := limiter.reserve(); delay != 0 {
+			t.Fatalf("reserve() #%d = %v, want the burst to pass", i, delay)
+		}
+	}
+	if delay := limiter.reserve(); delay != 500*time.Millisecond {
+		t.Errorf("reserve() after burst = %v, want 500ms", delay)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Errorf("reserve() after refill = %v, want 0", delay)
+	}
+
+	limiter.pause(2 * time.Second)
+	now = now.Add(10 * time.Second)
+	if delay := limiter.reserve(); delay != 0 {
+		t.Errorf("reserve() after pause = %v, want 0", delay)
+	}
+	limiter.pause(time.Second)
+	if delay := limiter.reserve(); delay != time.Second {
+		t.Errorf("reserve() while paused = %v, want 1s", delay)
+	}
+
+	if newRateLimiter(0, 5) != nil {
+		t.Error("a zero rate should disable limiting")
+	}
+	var disabled *rateLimiter
+	if err := disabled.wait(context.Background()); err != nil {
+		t.Errorf("nil limiter wait() error = %v", err)
+	}
+}
+
+func TestRateLimiter_WaitHonoursContext(t *testing.T) {
+	limiter := newRateLimiter(0.001, 1)
+	limiter.reserve()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("wait() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", time.Second},
+		{"3", 3 * time.Second},
+		{now.Add(5 * time.Second).Format(http.TimeFormat), 5 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"soon", time.Second},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if tt.header != "" {
+			resp.Header.Set("Retry-After", tt.header)
+		}
+		if got := retryAfter(resp, now, time.Second); got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestClient_RetriesAfterTooManyRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`[{"name":"Germany","iso_3166_1":"DE"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL), WithRetry(1, 0))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	countries, err := client.Countries(context.Background())
+	if err != nil {
+		t.Fatalf("Countries() error = %v", err)
+	}
+	if len(countries) != 1 || calls.Load() != 2 {
+		t.Errorf("countries = %+v after %d calls, want a retry on the same server", countries, calls.Load())
+	}
+}
+
+func TestClient_TooManyRequests_LongRetryAfterFails(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TestApp/1.0", WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	start := time.Now()
+	if _, err := client.Countries(context.Background()); err == nil {
+		t.Error("Countries() should fail when the server asks to wait an hour")
+	}
+	if calls.Load() != 1 || time.Since(start) > 5*time.Second {
+		t.Errorf("calls = %d, elapsed = %v; want a prompt failure", calls.Load(), time.Since(start))
+	}
+}
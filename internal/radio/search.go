@@ -0,0 +1,152 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchOrders lists the fields the API can sort search results by.
+var SearchOrders = []string{
+	"clickcount",
+	"votes",
+	"clicktrend",
+	"name",
+	"bitrate",
+	"country",
+	"language",
+	"codec",
+	"clicktimestamp",
+	"changetimestamp",
+	"lastchecktime",
+	"random",
+}
+
+// SearchParams selects stations for Client.Search. Zero-valued fields are not
+// filtered on; by default results are ordered by click count, most popular first.
+type SearchParams struct {
+	Name        string
+	CountryCode string
+	State       string
+	Language    string
+	// Tags must all be present on a station.
+	Tags       []string
+	Codec      string
+	MinBitrate int
+	MaxBitrate int
+	// HasGeo restricts results to stations with map coordinates.
+	HasGeo bool
+	// Near restricts results to stations within RadiusKm of a position.
+	Near     *Point
+	RadiusKm float64
+	// Order is one of SearchOrders; empty means "clickcount".
+	Order string
+	// Ascending sorts from lowest to highest instead of highest first.
+	Ascending bool
+	Limit     int
+	Offset    int
+}
+
+// Values encodes the parameters as a /json/stations/search query.
+func (p SearchParams) Values() (url.Values, error) {
+	limit, offset, err := sanitizePage(p.Limit, p.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	order := strings.ToLower(strings.TrimSpace(p.Order))
+	if order == "" {
+		order = "clickcount"
+	}
+	if !validOrder(order) {
+		return nil, fmt.Errorf("unsupported order %q", p.Order)
+	}
+	if p.MinBitrate < 0 || p.MaxBitrate < 0 {
+		return nil, errors.New("bitrate must be >= 0")
+	}
+	if p.MaxBitrate > 0 && p.MinBitrate > p.MaxBitrate {
+		return nil, errors.New("minimum bitrate is above maximum bitrate")
+	}
+
+	query := stationQuery(limit, offset)
+	query.Set("order", order)
+	query.Set("reverse", strconv.FormatBool(!p.Ascending))
+	setIfPresent(query, "name", p.Name)
+	setIfPresent(query, "countrycodeexact", strings.ToUpper(strings.TrimSpace(p.CountryCode)))
+	setIfPresent(query, "state", p.State)
+	setIfPresent(query, "language", strings.ToLower(strings.TrimSpace(p.Language)))
+	setIfPresent(query, "codec", p.Codec)
+	if tags := cleanTags(p.Tags); len(tags) > 0 {
+		query.Set("tagList", strings.Join(tags, ","))
+	}
+	if p.MinBitrate > 0 {
+		query.Set("bitrateMin", strconv.Itoa(p.MinBitrate))
+	}
+	if p.MaxBitrate > 0 {
+		query.Set("bitrateMax", strconv.Itoa(p.MaxBitrate))
+	}
+	if p.HasGeo || p.Near != nil {
+		query.Set("has_geo_info", "true")
+	}
+	if p.Near != nil {
+		if !p.Near.Valid() {
+			return nil, errors.New("position is out of range")
+		}
+		radius := p.RadiusKm
+		if radius <= 0 {
+			radius = DefaultNearbyRadiusKm
+		}
+		query.Set("geo_lat", strconv.FormatFloat(p.Near.Lat, 'f', -1, 64))
+		query.Set("geo_long", strconv.FormatFloat(p.Near.Long, 'f', -1, 64))
+		query.Set("geo_distance", strconv.FormatFloat(radius*1000, 'f', 0, 64))
+	}
+	return query, nil
+}
+
+// Search queries /json/stations/search with the given filters.
+func (c *Client) Search(ctx context.Context, params SearchParams) ([]Station, error) {
+	query, err := params.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var stations []Station
+	if err := c.doJSON(ctx, "/json/stations/search?"+query.Encode(), &stations); err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+// ParseTags splits a comma-separated tag list, dropping blanks.
+func ParseTags(text string) []string {
+	return cleanTags(strings.Split(text, ","))
+}
+
+func cleanTags(tags []string) []string {
+	cleaned := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			cleaned = append(cleaned, tag)
+		}
+	}
+	return cleaned
+}
+
+func validOrder(order string) bool {
+	for _, candidate := range SearchOrders {
+		if candidate == order {
+			return true
+		}
+	}
+	return false
+}
+
+func setIfPresent(query url.Values, key string, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		query.Set(key, value)
+	}
+}
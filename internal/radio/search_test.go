@@ -0,0 +1,122 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSearchParams_Values(t *testing.T) {
+	query, err := SearchParams{
+		Name:        " jazz ",
+		CountryCode: "us",
+		State:       "Texas",
+		Language:    "English",
+		Tags:        []string{"Smooth", " ", "late night"},
+		Codec:       "AAC",
+		MinBitrate:  96,
+		MaxBitrate:  320,
+		HasGeo:      true,
+		Order:       "Votes",
+		Limit:       20,
+		Offset:      40,
+	}.Values()
+	if err != nil {
+		t.Fatalf("Values() error = %v", err)
+	}
+
+	want := map[string]string{
+		"name":             "jazz",
+		"countrycodeexact": "US",
+		"state":            "Texas",
+		"language":         "english",
+		"tagList":          "smooth,late night",
+		"codec":            "AAC",
+		"bitrateMin":       "96",
+		"bitrateMax":       "320",
+		"has_geo_info":     "true",
+		"order":            "votes",
+		"reverse":          "true",
+		"hidebroken":       "true",
+		"limit":            "20",
+		"offset":           "40",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestSearchParams_Values_Defaults(t *testing.T) {
+	query, err := SearchParams{Limit: 10, Ascending: true}.Values()
+	if err != nil {
+		t.Fatalf("Values() error = %v", err)
+	}
+	if query.Get("order") != "clickcount" || query.Get("reverse") != "false" {
+		t.Errorf("order/reverse = %q/%q, want clickcount/false", query.Get("order"), query.Get("reverse"))
+	}
+	for _, key := range []string{"name", "countrycodeexact", "tagList", "bitrateMin", "bitrateMax", "has_geo_info"} {
+		if _, ok := query[key]; ok {
+			t.Errorf("%s should be omitted when unset", key)
+		}
+	}
+}
+
+func TestSearchParams_Values_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		params SearchParams
+	}{
+		{"no limit", SearchParams{}},
+		{"bad order", SearchParams{Limit: 10, Order: "loudness"}},
+		{"negative bitrate", SearchParams{Limit: 10, MinBitrate: -1}},
+		{"min above max", SearchParams{Limit: 10, MinBitrate: 256, MaxBitrate: 128}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.params.Values(); err == nil {
+				t.Error("Values() should return an error")
+			}
+		})
+	}
+}
+
+func TestClient_Search(t *testing.T) {
+	var captured url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/stations/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		captured = r.URL.Query()
+		json.NewEncoder(w).Encode([]Station{{UUID: "a"}})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		baseURL:   server.URL,
+		userAgent: "TestApp/1.0",
+		http:      &http.Client{Timeout: 5 * time.Second},
+	}
+	result, err := client.Search(context.Background(), SearchParams{Tags: []string{"rock"}, Order: "bitrate", Limit: 5})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("got %d stations, want 1", len(result))
+	}
+	if captured.Get("tagList") != "rock" || captured.Get("order") != "bitrate" {
+		t.Errorf("query = %v", captured)
+	}
+}
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" Rock, ,Jazz ,")
+	if len(got) != 2 || got[0] != "rock" || got[1] != "jazz" {
+		t.Errorf("ParseTags() = %v", got)
+	}
+}
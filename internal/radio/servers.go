@@ -0,0 +1,138 @@
+package radio
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// failureCooldown is how long a server is demoted after its first failure.
+	// Consecutive failures double it, up to maxFailureCooldown.
+	failureCooldown    = 30 * time.Second
+	maxFailureCooldown = 10 * time.Minute
+)
+
+// ServerHealth is a snapshot of what the client has observed about one mirror.
+type ServerHealth struct {
+	BaseURL     string
+	Latency     time.Duration
+	Failures    int
+	LastFailure time.Time
+}
+
+// serverPool tracks the known API mirrors and orders them by health.
+type serverPool struct {
+	mu      sync.Mutex
+	servers []*ServerHealth
+	now     func() time.Time
+}
+
+func newServerPool(baseURLs []string) *serverPool {
+	pool := &serverPool{now: time.Now}
+	seen := map[string]bool{}
+	for _, baseURL := range baseURLs {
+		baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+		if baseURL == "" || seen[baseURL] {
+			continue
+		}
+		seen[baseURL] = true
+		pool.servers = append(pool.servers, &ServerHealth{BaseURL: baseURL})
+	}
+	return pool
+}
+
+// ordered returns base URLs healthiest first: servers that are not cooling down
+// after a failure, fastest measured latency first, then unmeasured servers in
+// their original order, then cooling-down servers that failed longest ago.
+func (p *serverPool) ordered() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	servers := append([]*ServerHealth{}, p.servers...)
+	sort.SliceStable(servers, func(i, j int) bool {
+		a, b := servers[i], servers[j]
+		aDown, bDown := coolingDown(a, now), coolingDown(b, now)
+		if aDown != bDown {
+			return !aDown
+		}
+		if aDown {
+			return a.LastFailure.Before(b.LastFailure)
+		}
+		if (a.Latency == 0) != (b.Latency == 0) {
+			return a.Latency != 0
+		}
+		return a.Latency < b.Latency
+	})
+
+	urls := make([]string, len(servers))
+	for i, server := range servers {
+		urls[i] = server.BaseURL
+	}
+	return urls
+}
+
+func (p *serverPool) recordSuccess(baseURL string, latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	server := p.find(baseURL)
+	if server == nil {
+		return
+	}
+	server.Failures = 0
+	if latency <= 0 {
+		latency = time.Nanosecond
+	}
+	if server.Latency == 0 {
+		server.Latency = latency
+	} else {
+		// Smooth out jitter so a single slow response does not reorder mirrors.
+		server.Latency = (3*server.Latency + latency) / 4
+	}
+}
+
+func (p *serverPool) recordFailure(baseURL string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	server := p.find(baseURL)
+	if server == nil {
+		return
+	}
+	server.Failures++
+	server.LastFailure = p.now()
+}
+
+func (p *serverPool) snapshot() []ServerHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	health := make([]ServerHealth, len(p.servers))
+	for i, server := range p.servers {
+		health[i] = *server
+	}
+	return health
+}
+
+func (p *serverPool) find(baseURL string) *ServerHealth {
+	for _, server := range p.servers {
+		if server.BaseURL == baseURL {
+			return server
+		}
+	}
+	return nil
+}
+
+func coolingDown(server *ServerHealth, now time.Time) bool {
+	if server.Failures == 0 {
+		return false
+	}
+	cooldown := maxFailureCooldown
+	if server.Failures < 16 {
+		cooldown = min(failureCooldown<<(server.Failures-1), maxFailureCooldown)
+	}
+	return now.Sub(server.LastFailure) < cooldown
+}
@@ -0,0 +1,263 @@
+package radio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type mirror struct {
+	*httptest.Server
+	requests atomic.Int32
+	status   atomic.Int32
+}
+
+// newMirror starts a fake API server answering with status (200 serves a station list).
+func newMirror(t *testing.T, status int) *mirror {
+	t.Helper()
+	m := &mirror{}
+	m.status.Store(int32(status))
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.requests.Add(1)
+		if code := int(m.status.Load()); code != http.StatusOK {
+			w.WriteHeader(code)
+			return
+		}
+		json.NewEncoder(w).Encode([]Station{{UUID: "a", Name: "Alpha"}})
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func newFailoverClient(t *testing.T, baseURLs ...string) *Client {
+	t.Helper()
+	client, err := NewClient("TestApp/1.0", WithServers(baseURLs...), WithRetry(3, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_FailsOverOnServerError(t *testing.T) {
+	bad := newMirror(t, http.StatusBadGateway)
+	good := newMirror(t, http.StatusOK)
+	client := newFailoverClient(t, bad.URL, good.URL)
+
+	result, err := client.StationsByCountry(context.Background(), "US")
+	if err != nil {
+		t.Fatalf("StationsByCountry() error = %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("got %d stations, want 1", len(result))
+	}
+
+	// The failed mirror is demoted, so the next request goes straight to the good one.
+	if _, err := client.StationsByCountry(context.Background(), "US"); err != nil {
+		t.Fatalf("second call error = %v", err)
+	}
+	if bad.requests.Load() != 1 || good.requests.Load() != 2 {
+		t.Errorf("requests bad/good = %d/%d, want 1/2", bad.requests.Load(), good.requests.Load())
+	}
+
+	health := client.ServerHealth()
+	if health[0].BaseURL != bad.URL || health[0].Failures != 1 {
+		t.Errorf("bad mirror health = %+v, want 1 failure", health[0])
+	}
+	if health[1].Failures != 0 || health[1].Latency == 0 {
+		t.Errorf("good mirror health = %+v, want measured latency and no failures", health[1])
+	}
+}
+
+func TestClient_FailsOverOnNetworkError(t *testing.T) {
+	dead := newMirror(t, http.StatusOK)
+	dead.Close()
+	good := newMirror(t, http.StatusOK)
+	client := newFailoverClient(t, dead.URL, good.URL)
+
+	if _, err := client.Countries(context.Background()); err != nil {
+		t.Fatalf("Countries() error = %v", err)
+	}
+	if good.requests.Load() != 1 {
+		t.Errorf("good mirror requests = %d, want 1", good.requests.Load())
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	first := newMirror(t, http.StatusNotFound)
+	second := newMirror(t, http.StatusOK)
+	client := newFailoverClient(t, first.URL, second.URL)
+
+	if _, err := client.StationsByCountry(context.Background(), "US"); err == nil {
+		t.Fatal("expected 404 error")
+	}
+	if second.requests.Load() != 0 {
+		t.Errorf("4xx should not be retried on another mirror, got %d requests", second.requests.Load())
+	}
+	if health := client.ServerHealth(); health[0].Failures != 0 {
+		t.Errorf("4xx should not count as a server failure, got %+v", health[0])
+	}
+}
+
+func TestClient_VoteAndClickAreNotRetried(t *testing.T) {
+	first := newMirror(t, http.StatusBadGateway)
+	second := newMirror(t, http.StatusOK)
+	client := newFailoverClient(t, first.URL, second.URL)
+
+	if err := client.Vote(context.Background(), "a"); err == nil {
+		t.Fatal("Vote() expected 502 error")
+	}
+	if _, err := client.CountClick(context.Background(), "a"); err == nil {
+		t.Fatal("CountClick() expected 502 error")
+	}
+	if first.requests.Load() != 2 || second.requests.Load() != 0 {
+		t.Errorf("requests first/second = %d/%d, want 2/0", first.requests.Load(), second.requests.Load())
+	}
+}
+
+func TestClient_RetryAttemptsAreBounded(t *testing.T) {
+	mirrors := []*mirror{
+		newMirror(t, http.StatusServiceUnavailable),
+		newMirror(t, http.StatusServiceUnavailable),
+		newMirror(t, http.StatusServiceUnavailable),
+	}
+	client, err := NewClient("TestApp/1.0",
+		WithServers(mirrors[0].URL, mirrors[1].URL, mirrors[2].URL),
+		WithRetry(2, time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	if _, err := client.StationsByCountry(context.Background(), "US"); err == nil {
+		t.Fatal("expected error when every mirror fails")
+	}
+	total := 0
+	for _, m := range mirrors {
+		total += int(m.requests.Load())
+	}
+	if total != 2 {
+		t.Errorf("total requests = %d, want 2", total)
+	}
+}
+
+func TestClient_BackoffHonoursContext(t *testing.T) {
+	bad := newMirror(t, http.StatusServiceUnavailable)
+	good := newMirror(t, http.StatusOK)
+	client, err := NewClient("TestApp/1.0", WithServers(bad.URL, good.URL), WithRetry(2, time.Minute))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.StationsByCountry(ctx, "US"); err == nil {
+		t.Fatal("expected context error during backoff")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("backoff ignored cancellation, took %v", elapsed)
+	}
+	if good.requests.Load() != 0 {
+		t.Error("cancelled request should not reach the next mirror")
+	}
+}
+
+func TestServerPool_Ordering(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pool := newServerPool([]string{"a", "b", "c", "d", "a"})
+	pool.now = func() time.Time { return now }
+
+	if got := pool.ordered(); len(got) != 4 || got[0] != "a" || got[3] != "d" {
+		t.Fatalf("unmeasured servers should keep discovery order without duplicates, got %v", got)
+	}
+
+	pool.recordSuccess("c", 80*time.Millisecond)
+	pool.recordSuccess("b", 20*time.Millisecond)
+	pool.recordFailure("a")
+	got := pool.ordered()
+	want := []string{"b", "c", "d", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ordered() = %v, want %v", got, want)
+		}
+	}
+
+	// After the cooldown the failed server competes again as unmeasured.
+	now = now.Add(failureCooldown + time.Second)
+	if got := pool.ordered(); got[3] != "d" || got[2] != "a" {
+		t.Errorf("ordered() after cooldown = %v, want a before d", got)
+	}
+}
+
+func TestServerPool_CooldownGrows(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := &ServerHealth{BaseURL: "a", Failures: 3, LastFailure: now}
+
+	if !coolingDown(server, now.Add(3*failureCooldown)) {
+		t.Error("third failure should cool down for 4x the base cooldown")
+	}
+	if coolingDown(server, now.Add(4*failureCooldown)) {
+		t.Error("cooldown should expire after 4x the base cooldown")
+	}
+
+	server.Failures = 100
+	if coolingDown(server, now.Add(maxFailureCooldown)) {
+		t.Error("cooldown should be capped")
+	}
+}
+
+func TestServerPool_LatencySmoothing(t *testing.T) {
+	pool := newServerPool([]string{"a"})
+	pool.recordSuccess("a", 100*time.Millisecond)
+	pool.recordSuccess("a", 500*time.Millisecond)
+	if got := pool.snapshot()[0].Latency; got != 200*time.Millisecond {
+		t.Errorf("Latency = %v, want 200ms", got)
+	}
+}
+
+func TestClient_FixedServersAndHeaders(t *testing.T) {
+	var discovery atomic.Int32
+	var auth atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/json/servers" {
+			discovery.Add(1)
+		}
+		auth.Store(r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode([]Country{{Code: "US", Name: "United States"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("TestApp/1.0",
+		WithServers(server.URL+"/"),
+		WithHeaders(map[string]string{"Authorization": "Bearer lab-token"}))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if _, err := client.Countries(context.Background()); err != nil {
+		t.Fatalf("Countries() error = %v", err)
+	}
+
+	if discovery.Load() != 0 {
+		t.Error("fixed servers should skip /json/servers discovery")
+	}
+	if got, _ := auth.Load().(string); got != "Bearer lab-token" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer lab-token")
+	}
+	if health := client.ServerHealth(); len(health) != 1 || health[0].BaseURL != server.URL {
+		t.Errorf("ServerHealth() = %+v, want trailing slash trimmed", health)
+	}
+}
+
+func TestNewClient_RejectsUnsafeServerConfig(t *testing.T) {
+	if _, err := NewClient("TestApp/1.0", WithServers(" ", "")); err == nil {
+		t.Error("NewClient() should reject an empty server list")
+	}
+	// Refused before discovery, so no lookups are made.
+	_, err := NewClient("TestApp/1.0", WithHeaders(map[string]string{"Authorization": "Bearer lab-token"}))
+	if err == nil {
+		t.Error("NewClient() should refuse headers without fixed servers")
+	}
+}
@@ -4,22 +4,47 @@ import (
 	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Station represents a station from Radio Browser API.
 type Station struct {
-	UUID        string    `json:"stationuuid"`
-	Name        string    `json:"name"`
-	Country     string    `json:"country"`
-	CountryCode string    `json:"countrycode"`
-	Tags        string    `json:"tags"`
-	Bitrate     int       `json:"bitrate"`
-	Frequency   Frequency `json:"frequency"`
-	URLResolved string    `json:"url_resolved"`
-	URL         string    `json:"url"`
-	Favicon     string    `json:"favicon"`
-	ClickCount  int       `json:"clickcount"`
-	IsBroken    bool      `json:"is_broken"`
+	UUID            string     `json:"stationuuid"`
+	Name            string     `json:"name"`
+	Country         string     `json:"country"`
+	CountryCode     string     `json:"countrycode"`
+	State           string     `json:"state"`
+	Language        string     `json:"language"`
+	LanguageCodes   string     `json:"languagecodes"`
+	Tags            string     `json:"tags"`
+	Bitrate         int        `json:"bitrate"`
+	Codec           string     `json:"codec"`
+	HLS             Flag       `json:"hls"`
+	Frequency       Frequency  `json:"frequency"`
+	URLResolved     string     `json:"url_resolved"`
+	URL             string     `json:"url"`
+	Homepage        string     `json:"homepage"`
+	Favicon         string     `json:"favicon"`
+	Votes           int        `json:"votes"`
+	ClickCount      int        `json:"clickcount"`
+	ClickTrend      int        `json:"clicktrend"`
+	LastCheckOK     Flag       `json:"lastcheckok"`
+	LastChangeTime  Timestamp  `json:"lastchangetime"`
+	GeoLat          Coordinate `json:"geo_lat"`
+	GeoLong         Coordinate `json:"geo_long"`
+	HasExtendedInfo bool       `json:"has_extended_info"`
+	IsBroken        bool       `json:"is_broken"`
+}
+
+// HasGeo reports whether the station has map coordinates.
+func (s Station) HasGeo() bool {
+	return s.GeoLat != 0 || s.GeoLong != 0
+}
+
+// LastCheckFailed reports whether Radio Browser's most recent stream check
+// failed. Stations without check data (e.g. favorites) are not reported.
+func (s Station) LastCheckFailed() bool {
+	return s.LastCheckOK == FlagFalse
 }
 
 // Frequency captures station frequency when provided by the API.
@@ -30,28 +55,135 @@ func (f Frequency) Float64() float64 {
 }
 
 func (f *Frequency) UnmarshalJSON(data []byte) error {
+	number, ok := parseLooseFloat(data)
+	if ok {
+		*f = Frequency(number)
+	}
+	return nil
+}
+
+// Coordinate is a latitude or longitude, which the API sends as a number,
+// a string or null.
+type Coordinate float64
+
+func (c Coordinate) Float64() float64 {
+	return float64(c)
+}
+
+func (c *Coordinate) UnmarshalJSON(data []byte) error {
+	number, ok := parseLooseFloat(data)
+	if ok {
+		*c = Coordinate(number)
+	}
+	return nil
+}
+
+// parseLooseFloat decodes a JSON number or numeric string. Empty or invalid
+// strings yield 0; anything else (null, objects) is reported as not ok so
+// the target keeps its current value.
+func parseLooseFloat(data []byte) (float64, bool) {
 	var number float64
 	if err := json.Unmarshal(data, &number); err == nil {
-		*f = Frequency(number)
-		return nil
+		return number, true
 	}
 
 	var text string
 	if err := json.Unmarshal(data, &text); err != nil {
-		return nil
+		return 0, false
 	}
 
 	text = strings.TrimSpace(text)
 	if text == "" {
-		*f = 0
-		return nil
+		return 0, true
 	}
 
 	number, err := strconv.ParseFloat(text, 64)
 	if err != nil {
-		*f = 0
+		return 0, true
+	}
+	return number, true
+}
+
+// Flag is a yes/no field the API encodes as 0/1 (and occasionally as a
+// boolean or string). The zero value means the field was absent.
+type Flag int8
+
+const (
+	FlagUnknown Flag = iota
+	FlagFalse
+	FlagTrue
+)
+
+// True reports whether the flag is set.
+func (f Flag) True() bool {
+	return f == FlagTrue
+}
+
+func (f Flag) MarshalJSON() ([]byte, error) {
+	switch f {
+	case FlagTrue:
+		return []byte("1"), nil
+	case FlagFalse:
+		return []byte("0"), nil
+	}
+	return []byte("null"), nil
+}
+
+func (f *Flag) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value bool
+	if err := json.Unmarshal(data, &value); err == nil {
+		*f = flagOf(value)
+		return nil
+	}
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		*f = flagOf(number != 0)
 		return nil
 	}
-	*f = Frequency(number)
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		if value, err := strconv.ParseBool(strings.TrimSpace(text)); err == nil {
+			*f = flagOf(value)
+		}
+	}
+	return nil
+}
+
+func flagOf(value bool) Flag {
+	if value {
+		return FlagTrue
+	}
+	return FlagFalse
+}
+
+// timestampLayouts are the formats the API has used for its time fields.
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+// Timestamp is an API time such as "2024-01-31 18:04:05" (UTC). Empty or
+// unparseable values decode to the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return nil
+	}
+	text = strings.TrimSpace(text)
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	t.Time = time.Time{}
 	return nil
 }
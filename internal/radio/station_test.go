@@ -3,6 +3,7 @@ package radio
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestFrequency_Float64(t *testing.T) {
@@ -178,3 +179,120 @@ func TestStation_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestFlag_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected Flag
+	}{
+		{`1`, FlagTrue},
+		{`0`, FlagFalse},
+		{`true`, FlagTrue},
+		{`false`, FlagFalse},
+		{`"1"`, FlagTrue},
+		{`"true"`, FlagTrue},
+		{`"0"`, FlagFalse},
+		{`null`, FlagUnknown},
+		{`"maybe"`, FlagUnknown},
+	}
+
+	for _, tt := range tests {
+		var f Flag
+		if err := json.Unmarshal([]byte(tt.json), &f); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) error = %v", tt.json, err)
+		}
+		if f != tt.expected {
+			t.Errorf("UnmarshalJSON(%s) = %v, want %v", tt.json, f, tt.expected)
+		}
+	}
+}
+
+func TestFlag_RoundTrip(t *testing.T) {
+	for _, f := range []Flag{FlagUnknown, FlagFalse, FlagTrue} {
+		data, err := json.Marshal(f)
+		if err != nil {
+			t.Fatalf("Marshal(%v) error = %v", f, err)
+		}
+		var got Flag
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if got != f {
+			t.Errorf("round trip of %v = %v", f, got)
+		}
+	}
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected time.Time
+	}{
+		{`"2024-01-31 18:04:05"`, time.Date(2024, 1, 31, 18, 4, 5, 0, time.UTC)},
+		{`"2024-01-31T18:04:05Z"`, time.Date(2024, 1, 31, 18, 4, 5, 0, time.UTC)},
+		{`""`, time.Time{}},
+		{`"garbage"`, time.Time{}},
+		{`null`, time.Time{}},
+		{`12345`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.json), &ts); err != nil {
+			t.Fatalf("UnmarshalJSON(%s) error = %v", tt.json, err)
+		}
+		if !ts.Equal(tt.expected) {
+			t.Errorf("UnmarshalJSON(%s) = %v, want %v", tt.json, ts.Time, tt.expected)
+		}
+	}
+}
+
+func TestStation_UnmarshalJSON_FullSchema(t *testing.T) {
+	data := `{
+		"stationuuid": "abc",
+		"name": "Test FM",
+		"state": "Texas",
+		"language": "english,spanish",
+		"languagecodes": "en,es",
+		"homepage": "https://test.fm",
+		"codec": "AAC",
+		"hls": 0,
+		"votes": 42,
+		"clicktrend": -3,
+		"lastcheckok": 0,
+		"lastchangetime": "2024-01-31 18:04:05",
+		"geo_lat": "30.27",
+		"geo_long": -97.74,
+		"has_extended_info": true
+	}`
+
+	var station Station
+	if err := json.Unmarshal([]byte(data), &station); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if station.State != "Texas" || station.Language != "english,spanish" || station.LanguageCodes != "en,es" {
+		t.Errorf("location/language fields = %q/%q/%q", station.State, station.Language, station.LanguageCodes)
+	}
+	if station.Homepage != "https://test.fm" || station.Votes != 42 || station.ClickTrend != -3 {
+		t.Errorf("homepage/votes/clicktrend = %q/%d/%d", station.Homepage, station.Votes, station.ClickTrend)
+	}
+	if station.HLS != FlagFalse || !station.LastCheckFailed() || !station.HasExtendedInfo {
+		t.Errorf("flags hls/lastcheckok/extended = %v/%v/%v", station.HLS, station.LastCheckOK, station.HasExtendedInfo)
+	}
+	if station.LastChangeTime.Year() != 2024 {
+		t.Errorf("LastChangeTime = %v", station.LastChangeTime)
+	}
+	if !station.HasGeo() || station.GeoLat.Float64() != 30.27 || station.GeoLong.Float64() != -97.74 {
+		t.Errorf("geo = %v/%v", station.GeoLat, station.GeoLong)
+	}
+}
+
+func TestStation_LastCheckFailed_Unknown(t *testing.T) {
+	var station Station
+	if err := json.Unmarshal([]byte(`{"stationuuid":"abc"}`), &station); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if station.LastCheckFailed() || station.HasGeo() {
+		t.Error("stations without check or geo data should not report them")
+	}
+}
@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/player"
+	"radio-tui/internal/radio"
+)
+
+type addField int
+
+const (
+	addName addField = iota
+	addURL
+	addHomepage
+	addCountry
+	addTags
+	addLanguage
+	addTarget
+	addFieldCount
+
+	// addTextFields is the number of leading fields edited as text.
+	addTextFields = addTarget
+)
+
+// addProbeTimeout bounds the stream check before a station is submitted.
+const addProbeTimeout = 15 * time.Second
+
+var addLabels = [addFieldCount]string{
+	addName:     "Name",
+	addURL:      "Stream URL",
+	addHomepage: "Homepage",
+	addCountry:  "Country",
+	addTags:     "Tags",
+	addLanguage: "Language",
+	addTarget:   "Save to",
+}
+
+// addStationForm is the overlay used to submit a new station, or to save
+// it as a custom station in the favorites file. The stream is probed first
+// either way.
+type addStationForm struct {
+	inputs []textinput.Model
+	focus  addField
+	custom bool
+	status string
+	err    string
+	busy   bool
+	// seq identifies the current submission so results arriving after the
+	// form was closed or resubmitted are ignored.
+	seq int
+}
+
+type addStationProbedMsg struct {
+	seq     int
+	station radio.NewStation
+	result  player.ProbeResult
+	err     error
+}
+
+type addStationSubmittedMsg struct {
+	seq     int
+	station radio.NewStation
+	uuid    string
+	err     error
+}
+
+func newAddStationForm() addStationForm {
+	form := addStationForm{inputs: make([]textinput.Model, addTextFields)}
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = 32
+		form.inputs[i] = input
+	}
+	form.inputs[addURL].Placeholder = "http://..."
+	form.inputs[addHomepage].Placeholder = "optional"
+	form.inputs[addCountry].CharLimit = 2
+	form.inputs[addTags].Placeholder = "news, talk"
+	return form
+}
+
+func (f *addStationForm) setFocus(field addField) {
+	f.focus = (field + addFieldCount) % addFieldCount
+	for i := range f.inputs {
+		if addField(i) == f.focus {
+			f.inputs[i].Focus()
+			f.inputs[i].CursorEnd()
+		} else {
+			f.inputs[i].Blur()
+		}
+	}
+}
+
+func (f *addStationForm) blur() {
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+}
+
+func (f addStationForm) station() radio.NewStation {
+	value := func(field addField) string {
+		return strings.TrimSpace(f.inputs[field].Value())
+	}
+	return radio.NewStation{
+		Name:        value(addName),
+		URL:         value(addURL),
+		Homepage:    value(addHomepage),
+		CountryCode: strings.ToUpper(value(addCountry)),
+		Tags:        radio.ParseTags(value(addTags)),
+		Language:    value(addLanguage),
+	}
+}
+
+func (m Model) openAddStationForm() (tea.Model, tea.Cmd) {
+	seq := m.addForm.seq
+	m.addForm = newAddStationForm()
+	m.addForm.seq = seq
+	m.addForm.inputs[addCountry].SetValue(m.country)
+	m.addForm.setFocus(addName)
+	m.inputMode = inputAddStation
+	return m, textinput.Blink
+}
+
+func (m Model) updateAddStationForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "esc":
+		m.inputMode = inputNone
+		m.addForm.seq++
+		m.addForm.busy = false
+		m.addForm.blur()
+		return m, nil
+	}
+	if m.addForm.busy {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "tab", "down":
+		m.addForm.setFocus(m.addForm.focus + 1)
+		return m, nil
+	case "shift+tab", "up":
+		m.addForm.setFocus(m.addForm.focus - 1)
+		return m, nil
+	case "enter":
+		if m.addForm.custom && m.favorites == nil {
+			m.addForm.err = "Favorites are not available, so custom stations cannot be saved"
+			return m, nil
+		}
+		station := m.addForm.station()
+		if err := station.Validate(); err != nil {
+			m.addForm.err = err.Error()
+			return m, nil
+		}
+		m.addForm.seq++
+		m.addForm.busy = true
+		m.addForm.err = ""
+		m.addForm.status = "Checking stream..."
+		return m, probeNewStationCmd(m.addForm.seq, station)
+	}
+
+	if m.addForm.focus == addTarget {
+		switch key.String() {
+		case "left", "right", " ":
+			m.addForm.custom = !m.addForm.custom
+			m.addForm.err = ""
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.addForm.inputs[m.addForm.focus], cmd = m.addForm.inputs[m.addForm.focus].Update(msg)
+	m.addForm.err = ""
+	return m, cmd
+}
+
+func probeNewStationCmd(seq int, station radio.NewStation) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), addProbeTimeout)
+		defer cancel()
+		result, err := player.Probe(ctx, station.URL)
+		return addStationProbedMsg{seq: seq, station: station, result: result, err: err}
+	}
+}
+
+func (m Model) submitStationCmd(seq int, station radio.NewStation) tea.Cmd {
+	api := m.api
+	return func() tea.Msg {
+		if api == nil {
+			return addStationSubmittedMsg{seq: seq, station: station, err: fmt.Errorf("radio api not available")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		uuid, err := api.AddStation(ctx, station)
+		return addStationSubmittedMsg{seq: seq, station: station, uuid: uuid, err: err}
+	}
+}
+
+func (m Model) updateAddStationProbed(msg addStationProbedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.addForm.seq || m.inputMode != inputAddStation {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.addForm.busy = false
+		m.addForm.status = ""
+		m.addForm.err = "Stream check failed: " + msg.err.Error()
+		return m, nil
+	}
+	if m.addForm.custom {
+		return m.saveCustomStation(msg)
+	}
+	m.addForm.status = fmt.Sprintf("Stream OK (%s), submitting...", describeProbe(msg.result))
+	return m, m.submitStationCmd(msg.seq, msg.station)
+}
+
+func (m Model) updateAddStationSubmitted(msg addStationSubmittedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.addForm.seq || m.inputMode != inputAddStation {
+		return m, nil
+	}
+	m.addForm.busy = false
+	m.addForm.status = ""
+	if msg.err != nil {
+		m.addForm.err = "Submit failed: " + describeError(msg.err)
+		return m, nil
+	}
+	m.inputMode = inputNone
+	m.addForm.blur()
+	m.errMsg = fmt.Sprintf("Submitted %s to Radio Browser", msg.station.Name)
+	return m, nil
+}
+
+// saveCustomStation stores a probed station in the favorites file under a
+// locally generated ID; it is never sent to Radio Browser.
+func (m Model) saveCustomStation(msg addStationProbedMsg) (tea.Model, tea.Cmd) {
+	m.addForm.busy = false
+	m.addForm.status = ""
+	station, err := msg.station.CustomStation()
+	if err == nil {
+		station.Codec = msg.result.Codec
+		station.Bitrate = msg.result.Bitrate
+		err = m.favorites.Add(station)
+	}
+	if err != nil {
+		m.addForm.err = "Save failed: " + err.Error()
+		return m, nil
+	}
+	m.inputMode = inputNone
+	m.addForm.blur()
+	m.errMsg = fmt.Sprintf("Saved %s to favorites as a custom station", station.Name)
+	if m.stationSource != sourceFavorites {
+		return m, nil
+	}
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	return m, m.loadStationsCmd()
+}
+
+func describeProbe(result player.ProbeResult) string {
+	parts := []string{fallback(result.Codec, fallback(result.ContentType, "audio"))}
+	if result.Bitrate > 0 {
+		parts = append(parts, fmt.Sprintf("%d kbps", result.Bitrate))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m Model) renderAddStationForm(width int) string {
+	panelWidth := min(max(width, 10), 60)
+	title, action := "Add Station to Radio Browser", "Enter check & submit"
+	if m.addForm.custom {
+		title, action = "Add Custom Station", "Enter check & save"
+	}
+	lines := []string{m.styles.ListHeader.Render(title), ""}
+
+	for field := addField(0); field < addFieldCount; field++ {
+		marker := "  "
+		style := m.styles.ListItem
+		if field == m.addForm.focus {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		label := style.Render(fmt.Sprintf("%s%-10s", marker, addLabels[field]))
+		lines = append(lines, label+" "+m.addFieldValue(field))
+	}
+
+	if m.addForm.status != "" {
+		lines = append(lines, "", m.styles.Accent.Render(m.addForm.status))
+	}
+	if m.addForm.err != "" {
+		lines = append(lines, "", m.styles.Error.Render(m.addForm.err))
+	}
+	lines = append(lines, "", m.styles.Muted.Render("Tab/Up/Down move  Left/Right change  "+action+"  Esc cancel"))
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m Model) addFieldValue(field addField) string {
+	if field != addTarget {
+		return m.addForm.inputs[field].View()
+	}
+	if m.addForm.custom {
+		return "< Favorites only (custom station) >"
+	}
+	return "< Radio Browser >"
+}
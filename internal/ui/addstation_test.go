@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/generators"
+	"github.com/gopxl/beep/v2/wav"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+// newMockStationAPI serves a WAV stream at /live.wav, an HTML page at /page
+// and records /json/add submissions.
+func newMockStationAPI(t *testing.T) (*httptest.Server, func() []url.Values) {
+	t.Helper()
+	format := beep.Format{SampleRate: 22050, NumChannels: 2, Precision: 2}
+	path := filepath.Join(t.TempDir(), "live.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := wav.Encode(f, generators.Silence(4096), format); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	f.Close()
+
+	var (
+		mu    sync.Mutex
+		forms []url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/live.wav":
+			w.Header().Set("Content-Type", "audio/wav")
+			http.ServeFile(w, r, path)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>listen here</html>"))
+		case "/json/add":
+			r.ParseForm()
+			mu.Lock()
+			forms = append(forms, r.PostForm)
+			mu.Unlock()
+			w.Write([]byte(`{"ok":true,"message":"added station successfully","uuid":"new-uuid"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []url.Values {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]url.Values(nil), forms...)
+	}
+}
+
+func fillAddStationForm(t *testing.T, m Model, values map[addField]string) Model {
+	t.Helper()
+	for field := addField(0); field < addFieldCount; field++ {
+		if value, ok := values[field]; ok {
+			m.addForm.inputs[field].SetValue(value)
+		}
+	}
+	return m
+}
+
+func TestAddStation_ProbesThenSubmits(t *testing.T) {
+	server, submitted := newMockStationAPI(t)
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = updated.(Model)
+	if m.inputMode != inputAddStation || m.addForm.inputs[addCountry].Value() != "US" {
+		t.Fatalf("mode = %v, country = %q", m.inputMode, m.addForm.inputs[addCountry].Value())
+	}
+	m = typeText(t, m, "Local FM")
+	m = fillAddStationForm(t, m, map[addField]string{
+		addURL:  server.URL + "/live.wav",
+		addTags: "Local, News",
+	})
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if !m.addForm.busy || cmd == nil {
+		t.Fatalf("busy = %v; submitting should probe the stream first", m.addForm.busy)
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.addForm.status, "Stream OK (WAV)") || cmd == nil {
+		t.Fatalf("status = %q, err = %q", m.addForm.status, m.addForm.err)
+	}
+	if len(submitted()) != 0 {
+		t.Fatal("nothing should be submitted before the probe succeeds")
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	forms := submitted()
+	if len(forms) != 1 || forms[0].Get("name") != "Local FM" || forms[0].Get("tags") != "local,news" || forms[0].Get("countrycode") != "US" {
+		t.Fatalf("submissions = %v", forms)
+	}
+	if m.inputMode != inputNone || m.errMsg != "Submitted Local FM to Radio Browser" {
+		t.Errorf("mode = %v, errMsg = %q", m.inputMode, m.errMsg)
+	}
+}
+
+func TestAddStation_RejectsUnplayableStream(t *testing.T) {
+	server, submitted := newMockStationAPI(t)
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = updated.(Model)
+
+	// Validation errors are reported without any network access.
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if cmd != nil || !strings.Contains(m.addForm.err, "name is required") {
+		t.Fatalf("err = %q, cmd = %v", m.addForm.err, cmd)
+	}
+
+	m = fillAddStationForm(t, m, map[addField]string{addName: "Web Page FM", addURL: server.URL + "/page"})
+	m, cmd = pressKey(t, m, tea.KeyEnter)
+	updated, next := m.Update(cmd())
+	m = updated.(Model)
+	if next != nil || m.addForm.busy || !strings.Contains(m.addForm.err, "Stream check failed") {
+		t.Errorf("busy = %v, err = %q", m.addForm.busy, m.addForm.err)
+	}
+	if m.inputMode != inputAddStation || len(submitted()) != 0 {
+		t.Error("a failed probe should keep the form open and submit nothing")
+	}
+	if view := m.renderAddStationForm(60); !strings.Contains(view, "not an audio stream") {
+		t.Errorf("form should show the probe error, got %q", view)
+	}
+}
+
+func TestAddStation_SavesCustomStation(t *testing.T) {
+	useTempConfigDir(t)
+	server, submitted := newMockStationAPI(t)
+	favorites, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	m := *createTestModel()
+	m.favorites = favorites
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	m = updated.(Model)
+	m = fillAddStationForm(t, m, map[addField]string{addName: "Office Radio", addURL: server.URL + "/live.wav"})
+	m.addForm.setFocus(addTarget)
+	m, _ = pressKey(t, m, tea.KeyRight)
+	if !m.addForm.custom || !strings.Contains(m.renderAddStationForm(60), "Add Custom Station") {
+		t.Fatal("Right on the target field should switch to a custom station")
+	}
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.inputMode != inputNone || !strings.HasPrefix(m.errMsg, "Saved Office Radio") {
+		t.Fatalf("mode = %v, errMsg = %q, form err = %q", m.inputMode, m.errMsg, m.addForm.err)
+	}
+	if len(submitted()) != 0 {
+		t.Error("custom stations must not be submitted to Radio Browser")
+	}
+
+	favs := favorites.List()
+	if len(favs) != 1 || favs[0].Station == nil {
+		t.Fatalf("favorites = %+v", favs)
+	}
+	station := favoriteStation(favs[0])
+	if !station.Custom() || station.Codec != "WAV" {
+		t.Errorf("saved station = %+v", station)
+	}
+
+	// Playback uses the stored URL; m.api is nil, so any API use would fail.
+	msg, ok := m.playStationCmd(station)().(playMsg)
+	if !ok || msg.err != nil || msg.url != server.URL+"/live.wav" {
+		t.Errorf("playStationCmd() = %+v", msg)
+	}
+	if _, cmd := m.voteStation(station); cmd != nil {
+		t.Error("custom stations cannot be voted for")
+	}
+}
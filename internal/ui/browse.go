@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/radio"
+)
+
+type browseKind int
+
+const (
+	browseTag browseKind = iota
+	browseLanguage
+	browseKindCount
+)
+
+// browseTagLimit caps how many of the most used tags the selector lists.
+const browseTagLimit = 1000
+
+type browseItem struct {
+	Name  string
+	Count int
+}
+
+// browseSelector is the searchable tag/language overlay, modelled on the
+// country selector.
+type browseSelector struct {
+	kind     browseKind
+	search   textinput.Model
+	filtered []browseItem
+	index    int
+	// loading is tracked per kind so a reply for one overlay cannot leave
+	// the other waiting forever.
+	loading [browseKindCount]bool
+}
+
+type browseItemsMsg struct {
+	kind  browseKind
+	items []browseItem
+	err   error
+}
+
+func newBrowseSearch() textinput.Model {
+	search := textinput.New()
+	search.Prompt = "Search: "
+	search.Width = 26
+	return search
+}
+
+func (k browseKind) title() string {
+	if k == browseLanguage {
+		return "Browse by Language"
+	}
+	return "Browse by Tag"
+}
+
+func (m Model) openBrowse(kind browseKind) (tea.Model, tea.Cmd) {
+	if m.browse.search.Prompt == "" {
+		m.browse.search = newBrowseSearch()
+	}
+	m.browse.kind = kind
+	m.browse.index = 0
+	m.browse.search.Placeholder = "Type a tag"
+	if kind == browseLanguage {
+		m.browse.search.Placeholder = "Type a language"
+	}
+	m.browse.search.SetValue("")
+	m.browse.search.Focus()
+	m.inputMode = inputBrowse
+	m.applyBrowseFilter()
+
+	if len(m.browseItems[kind]) == 0 && !m.browse.loading[kind] {
+		m.browse.loading[kind] = true
+		return m, tea.Batch(textinput.Blink, m.loadBrowseItemsCmd(kind))
+	}
+	return m, textinput.Blink
+}
+
+func (m Model) loadBrowseItemsCmd(kind browseKind) tea.Cmd {
+	api := m.api
+	return func() tea.Msg {
+		if api == nil {
+			return browseItemsMsg{kind: kind, err: fmt.Errorf("radio api not available")}
+		}
+		ctx := context.Background()
+		var items []browseItem
+		switch kind {
+		case browseLanguage:
+			languages, err := api.Languages(ctx)
+			if err != nil {
+				return browseItemsMsg{kind: kind, err: err}
+			}
+			for _, language := range languages {
+				items = append(items, browseItem{Name: language.Name, Count: language.StationCount})
+			}
+		default:
+			tags, err := api.Tags(ctx, "", browseTagLimit)
+			if err != nil {
+				return browseItemsMsg{kind: kind, err: err}
+			}
+			for _, tag := range tags {
+				items = append(items, browseItem{Name: tag.Name, Count: tag.StationCount})
+			}
+		}
+
+		cleaned := items[:0]
+		for _, item := range items {
+			if strings.TrimSpace(item.Name) != "" {
+				cleaned = append(cleaned, item)
+			}
+		}
+		return browseItemsMsg{kind: kind, items: cleaned}
+	}
+}
+
+func (m Model) updateBrowseItems(msg browseItemsMsg) (tea.Model, tea.Cmd) {
+	m.browse.loading[msg.kind] = false
+	if msg.err != nil {
+		m.fail("", msg.err, retryBrowseItems(msg.kind))
+		return m, nil
+	}
+	m.browseItems[msg.kind] = msg.items
+	if msg.kind == m.browse.kind {
+		m.applyBrowseFilter()
+	}
+	return m, nil
+}
+
+func (m Model) updateBrowseSelect(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.browse.search, cmd = m.browse.search.Update(msg)
+	m.applyBrowseFilter()
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "up":
+			m.browse.index = max(m.browse.index-1, 0)
+		case "down":
+			m.browse.index = min(m.browse.index+1, max(len(m.browse.filtered)-1, 0))
+		case "enter":
+			value := ""
+			if m.browse.index < len(m.browse.filtered) {
+				value = m.browse.filtered[m.browse.index].Name
+			} else {
+				// Allow tags beyond the loaded list by taking the typed text.
+				value = strings.TrimSpace(m.browse.search.Value())
+			}
+			if value == "" {
+				return m, cmd
+			}
+			m.inputMode = inputNone
+			m.browse.search.Blur()
+			m.stationSource = sourceTag
+			if m.browse.kind == browseLanguage {
+				m.stationSource = sourceLanguage
+			}
+			m.activeBrowse = strings.ToLower(value)
+			m.page = 0
+			m.hasMore = false
+			m.selected = 0
+			m.activeSearch = ""
+			m.search.SetValue("")
+			m.loading = true
+			m.errMsg = ""
+			return m, m.loadStationsCmd()
+		case "esc":
+			m.inputMode = inputNone
+			m.browse.search.Blur()
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+func (m *Model) applyBrowseFilter() {
+	items := m.browseItems[m.browse.kind]
+	filter := strings.TrimSpace(strings.ToLower(m.browse.search.Value()))
+	if filter == "" {
+		m.browse.filtered = items
+	} else {
+		filtered := make([]browseItem, 0, len(items))
+		for _, item := range items {
+			if strings.Contains(strings.ToLower(item.Name), filter) {
+				filtered = append(filtered, item)
+			}
+		}
+		m.browse.filtered = filtered
+	}
+	if m.browse.index >= len(m.browse.filtered) {
+		m.browse.index = max(len(m.browse.filtered)-1, 0)
+	}
+}
+
+func (m Model) renderBrowseSelect(width int, height int) string {
+	panelWidth := width
+	if panelWidth <= 0 {
+		panelWidth = 10
+	}
+	innerWidth := innerWidthForPanel(panelWidth)
+	if innerWidth < 10 {
+		innerWidth = max(panelWidth-2, 4)
+	}
+
+	title := m.browse.kind.title()
+	if m.browse.loading[m.browse.kind] {
+		lines := []string{
+			m.styles.ListHeader.Render(title),
+			m.styles.Muted.Render("Loading..."),
+		}
+		return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	list := m.browse.filtered
+	lines := []string{
+		m.styles.ListHeader.Render(title),
+		m.styles.Meta.Render(m.browse.search.View()),
+	}
+
+	maxItems := max(height-12, 4)
+	if maxItems > 12 {
+		maxItems = 12
+	}
+	if len(list) == 0 {
+		label := "No matches"
+		if typed := strings.TrimSpace(m.browse.search.Value()); typed != "" {
+			label = fmt.Sprintf("No matches, Enter browses %q anyway", typed)
+		}
+		lines = append(lines, m.styles.Muted.Render(label))
+		return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	start, end := listWindow(len(list), m.browse.index, maxItems)
+	for i := start; i < end; i++ {
+		item := list[i]
+		marker := "  "
+		style := m.styles.ListItem
+		if i == m.browse.index {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		label := fmt.Sprintf("%s (%d)", item.Name, item.Count)
+		label = truncateText(label, innerWidth)
+		lines = append(lines, style.Width(innerWidth).MaxWidth(innerWidth).Render(marker+label))
+	}
+
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// browseStationsPage loads one page for the tag or language sources.
+func browseStationsPage(ctx context.Context, api *radio.Client, source stationSource, value string, search string, limit int, offset int) ([]radio.Station, error) {
+	if search != "" {
+		params := radio.SearchParams{Name: search, Limit: limit, Offset: offset}
+		if source == sourceLanguage {
+			params.Language = value
+		} else {
+			params.Tags = []string{value}
+		}
+		return api.Search(ctx, params)
+	}
+	if source == sourceLanguage {
+		return api.StationsByLanguage(ctx, value, limit, offset)
+	}
+	return api.StationsByTag(ctx, value, limit, offset)
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+func TestBrowseSelect_TagStations(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/json/tags"):
+			w.Write([]byte(`[{"name":"jazz","stationcount":120},{"name":"rock","stationcount":300},{"name":"","stationcount":5}]`))
+		default:
+			w.Write([]byte(`[{"stationuuid":"x","name":"Jazz FM"}]`))
+		}
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = updated.(Model)
+	if m.inputMode != inputBrowse || !m.browse.loading[browseTag] || cmd == nil {
+		t.Fatalf("mode/loading = %v/%v, cmd = %v", m.inputMode, m.browse.loading[browseTag], cmd)
+	}
+	items := m.loadBrowseItemsCmd(browseTag)().(browseItemsMsg)
+	if items.err != nil || len(items.items) != 2 {
+		t.Fatalf("items = %+v, err = %v", items.items, items.err)
+	}
+	updated, _ = m.Update(items)
+	m = updated.(Model)
+
+	m = typeText(t, m, "jaz")
+	if len(m.browse.filtered) != 1 || m.browse.filtered[0].Name != "jazz" {
+		t.Fatalf("filtered = %+v", m.browse.filtered)
+	}
+	if view := m.renderBrowseSelect(60, 30); !strings.Contains(view, "jazz (120)") {
+		t.Errorf("selector should list tag counts, got %q", view)
+	}
+
+	m, cmd = pressKey(t, m, tea.KeyEnter)
+	if m.inputMode != inputNone || m.stationSource != sourceTag || m.activeBrowse != "jazz" || cmd == nil {
+		t.Fatalf("mode/source/browse = %v/%v/%q", m.inputMode, m.stationSource, m.activeBrowse)
+	}
+	msg := cmd().(stationsMsg)
+	if last := paths[len(paths)-1]; last != "/json/stations/bytagexact/jazz" {
+		t.Errorf("path = %q", last)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.stations) != 1 || m.stations[0].Name != "Jazz FM" {
+		t.Errorf("stations = %+v", m.stations)
+	}
+	if header := m.renderList(80, 5); !strings.Contains(header, "Tag: jazz") {
+		t.Errorf("list header should name the tag, got %q", header)
+	}
+}
+
+func TestBrowseSelect_TypedLanguage(t *testing.T) {
+	m := *createTestModel()
+	m.inputMode = inputBrowse
+	m.browse.kind = browseLanguage
+	m.browse.search = newBrowseSearch()
+	m.browse.search.Focus()
+
+	m = typeText(t, m, "Mongolian")
+	m, _ = pressKey(t, m, tea.KeyEnter)
+	if m.stationSource != sourceLanguage || m.activeBrowse != "mongolian" {
+		t.Errorf("source/browse = %v/%q, want language mongolian", m.stationSource, m.activeBrowse)
+	}
+	if key := m.activeQueryKey(); key != "language:mongolian" {
+		t.Errorf("activeQueryKey() = %q", key)
+	}
+}
+
+func TestBrowseSelect_SwitchKindWhileLoading(t *testing.T) {
+	m := *createTestModel()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("opening the tag overlay should request tags")
+	}
+	m, _ = pressKey(t, m, tea.KeyEsc)
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	m = updated.(Model)
+	if m.browse.kind != browseLanguage || !m.browse.loading[browseLanguage] || cmd == nil {
+		t.Fatalf("kind/loading = %v/%v, languages should be requested while tags load", m.browse.kind, m.browse.loading)
+	}
+
+	updated, _ = m.Update(browseItemsMsg{kind: browseTag, items: []browseItem{{Name: "jazz", Count: 1}}})
+	m = updated.(Model)
+	if m.browse.loading[browseTag] || !m.browse.loading[browseLanguage] {
+		t.Fatalf("loading = %v after the tag reply", m.browse.loading)
+	}
+	updated, _ = m.Update(browseItemsMsg{kind: browseLanguage, items: []browseItem{{Name: "mongolian", Count: 1}}})
+	m = updated.(Model)
+	if m.browse.loading[browseLanguage] || len(m.browse.filtered) != 1 {
+		t.Fatalf("loading = %v, filtered = %+v", m.browse.loading, m.browse.filtered)
+	}
+	if view := m.renderBrowseSelect(60, 30); strings.Contains(view, "Loading...") {
+		t.Errorf("language overlay still loading: %q", view)
+	}
+}
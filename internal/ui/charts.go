@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/radio"
+)
+
+// chartPicker is the overlay used to choose a radio.Chart and its scope.
+type chartPicker struct {
+	index     int
+	worldwide bool
+}
+
+func (m Model) openChartPicker() (tea.Model, tea.Cmd) {
+	m.chartPicker.index = 0
+	for i, chart := range radio.Charts {
+		if chart == m.activeChart {
+			m.chartPicker.index = i
+		}
+	}
+	// Default to the current country only when browsing a country list.
+	m.chartPicker.worldwide = m.stationSource != sourceCountry
+	if m.stationSource == sourceCharts {
+		m.chartPicker.worldwide = m.chartCountry == ""
+	}
+	m.inputMode = inputChart
+	return m, nil
+}
+
+func (m Model) updateChartPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		m.chartPicker.index = max(m.chartPicker.index-1, 0)
+	case "down", "j":
+		m.chartPicker.index = min(m.chartPicker.index+1, len(radio.Charts)-1)
+	case "tab", "left", "right", " ":
+		m.chartPicker.worldwide = !m.chartPicker.worldwide
+	case "enter":
+		m.inputMode = inputNone
+		m.stationSource = sourceCharts
+		m.activeChart = radio.Charts[m.chartPicker.index]
+		m.chartCountry = ""
+		if !m.chartPicker.worldwide {
+			m.chartCountry = m.country
+		}
+		m.activeSearch = ""
+		m.search.SetValue("")
+		m.page = 0
+		m.hasMore = false
+		m.selected = 0
+		m.loading = true
+		m.errMsg = ""
+		return m, m.loadStationsCmd()
+	case "esc", "c", "C":
+		m.inputMode = inputNone
+	}
+	return m, nil
+}
+
+func (m Model) renderChartPicker(width int) string {
+	panelWidth := min(max(width, 10), 44)
+	lines := []string{m.styles.ListHeader.Render("Charts"), ""}
+
+	for i, chart := range radio.Charts {
+		marker := "  "
+		style := m.styles.ListItem
+		if i == m.chartPicker.index {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		lines = append(lines, style.Render(marker+chart.Title()))
+	}
+
+	scope := fmt.Sprintf("Scope: < %s >", m.chartScopeLabel(m.chartPicker.worldwide))
+	lines = append(lines, "", m.styles.Meta.Render(scope))
+	lines = append(lines, "", m.styles.Muted.Render("Up/Down choose  Tab scope  Enter show  Esc cancel"))
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m Model) chartScopeLabel(worldwide bool) string {
+	if worldwide {
+		return "Worldwide"
+	}
+	return strings.ToUpper(m.country)
+}
+
+// chartTitle describes the active chart for the list header.
+func (m Model) chartTitle() string {
+	scope := "Worldwide"
+	if m.chartCountry != "" {
+		scope = strings.ToUpper(m.chartCountry)
+	}
+	return fmt.Sprintf("%s - %s", m.activeChart.Title(), scope)
+}
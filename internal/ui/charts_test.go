@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+func TestChartPicker_SelectsChartAndScope(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+		w.Write([]byte(`[{"stationuuid":"a","name":"Top FM","countrycode":"FR"}]`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = updated.(Model)
+	if m.inputMode != inputChart || m.chartPicker.worldwide {
+		t.Fatalf("mode = %v, worldwide = %v; country lists should default to a country chart", m.inputMode, m.chartPicker.worldwide)
+	}
+
+	m, _ = pressKey(t, m, tea.KeyDown)
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if m.stationSource != sourceCharts || m.activeChart != radio.ChartTopVote || m.chartCountry != "US" || cmd == nil {
+		t.Fatalf("source/chart/country = %v/%q/%q", m.stationSource, m.activeChart, m.chartCountry)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(requests) != 1 || !strings.Contains(requests[0], "countrycodeexact=US") || !strings.Contains(requests[0], "order=votes") {
+		t.Fatalf("requests = %v", requests)
+	}
+	if header := m.renderList(80, 5); !strings.Contains(header, "Most voted - US") {
+		t.Errorf("list header should name the chart, got %q", header)
+	}
+
+	// Switch the same chart to worldwide scope.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = updated.(Model)
+	m, _ = pressKey(t, m, tea.KeyTab)
+	m, cmd = pressKey(t, m, tea.KeyEnter)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if last := requests[len(requests)-1]; !strings.HasPrefix(last, "/json/stations/topvote?") {
+		t.Errorf("worldwide chart request = %q", last)
+	}
+	if list := m.renderList(80, 5); !strings.Contains(list, "Most voted - Worldwide") || !strings.Contains(list, "FR") {
+		t.Errorf("worldwide chart should show country codes, got %q", list)
+	}
+}
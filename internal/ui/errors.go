@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+// retryFunc repeats a failed request against the current model.
+type retryFunc func(Model) (tea.Model, tea.Cmd)
+
+// describeError turns an API error into a message that says what went
+// wrong in the user's terms.
+func describeError(err error) string {
+	var (
+		uncertain   *radio.UncertainError
+		rateLimited *radio.RateLimitError
+		timeout     *radio.TimeoutError
+		network     *radio.NetworkError
+		notFound    *radio.NotFoundError
+		status      *radio.StatusError
+		decode      *radio.DecodeError
+	)
+	switch {
+	case errors.As(err, &uncertain):
+		return describeError(uncertain.Err) + "; it may still have gone through"
+	case errors.As(err, &rateLimited):
+		if rateLimited.RetryAfter > 0 {
+			return fmt.Sprintf("Radio Browser is busy; try again in %s", rateLimited.RetryAfter.Round(time.Second))
+		}
+		return "Radio Browser is busy; try again shortly"
+	case errors.As(err, &timeout), errors.Is(err, context.DeadlineExceeded):
+		return "Radio Browser took too long to answer"
+	case errors.As(err, &network):
+		return "Cannot reach Radio Browser; check your internet connection"
+	case errors.As(err, &notFound):
+		return "Station is no longer listed on Radio Browser"
+	case errors.As(err, &status):
+		switch {
+		case status.StatusCode >= 500:
+			return fmt.Sprintf("Radio Browser server error (HTTP %d)", status.StatusCode)
+		case status.StatusCode == http.StatusNotFound:
+			return "Not found on Radio Browser (HTTP 404)"
+		}
+		return fmt.Sprintf("Radio Browser refused the request (HTTP %d)", status.StatusCode)
+	case errors.As(err, &decode):
+		return "Radio Browser sent an unexpected response; the server may be under maintenance"
+	}
+	return err.Error()
+}
+
+// fail shows err on the error line. Transient failures remember retry so
+// R can repeat the request; anything else clears a pending retry.
+func (m *Model) fail(prefix string, err error, retry retryFunc) {
+	m.errMsg = prefix + describeError(err)
+	m.retry = nil
+	if retry != nil && radio.IsTransient(err) {
+		m.retry = retry
+		m.errMsg += " (R to retry)"
+	}
+}
+
+// retryLast repeats the request behind the last transient error.
+func (m Model) retryLast() (tea.Model, tea.Cmd) {
+	retry := m.retry
+	if retry == nil {
+		return m, nil
+	}
+	m.retry = nil
+	m.errMsg = ""
+	return retry(m)
+}
+
+func retryStations(m Model) (tea.Model, tea.Cmd) {
+	m.loading = true
+	return m, m.loadStationsCmd()
+}
+
+func retryCountries(m Model) (tea.Model, tea.Cmd) {
+	m.countryLoading = true
+	return m, m.loadCountriesCmd()
+}
+
+func retryBrowseItems(kind browseKind) retryFunc {
+	return func(m Model) (tea.Model, tea.Cmd) {
+		m.browse.loading[kind] = true
+		return m, m.loadBrowseItemsCmd(kind)
+	}
+}
+
+func retryPlay(station radio.Station) retryFunc {
+	return func(m Model) (tea.Model, tea.Cmd) {
+		return m, m.playStationCmd(station)
+	}
+}
+
+func retryVote(station radio.Station) retryFunc {
+	return func(m Model) (tea.Model, tea.Cmd) {
+		return m.voteStation(station)
+	}
+}
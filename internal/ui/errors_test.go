@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+func TestDescribeError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&radio.NetworkError{Err: errors.New("connection refused")}, "Cannot reach Radio Browser"},
+		{&radio.TimeoutError{Err: context.DeadlineExceeded}, "took too long"},
+		{context.DeadlineExceeded, "took too long"},
+		{&radio.RateLimitError{RetryAfter: 90 * time.Second}, "try again in 1m30s"},
+		{&radio.StatusError{StatusCode: http.StatusBadGateway}, "server error (HTTP 502)"},
+		{&radio.StatusError{StatusCode: http.StatusNotFound}, "Not found"},
+		{&radio.StatusError{StatusCode: http.StatusForbidden}, "refused the request (HTTP 403)"},
+		{fmt.Errorf("countries: %w", &radio.DecodeError{Err: errors.New("bad json")}), "unexpected response"},
+		{&radio.NotFoundError{UUID: "x"}, "no longer listed"},
+		{&radio.UncertainError{Err: &radio.StatusError{StatusCode: http.StatusBadGateway}}, "HTTP 502); it may still have gone through"},
+		{errors.New("something else"), "something else"},
+	}
+	for _, tt := range tests {
+		if got := describeError(tt.err); !strings.Contains(got, tt.want) {
+			t.Errorf("describeError(%v) = %q, want it to contain %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestModel_RetryTransientLoadError(t *testing.T) {
+	m := *createTestModel()
+	m.loading = true
+	failed := stationsMsg{source: m.stationSource, country: m.country, query: m.activeQueryKey(), err: &radio.NetworkError{Err: errors.New("connection refused")}}
+
+	updated, _ := m.Update(failed)
+	m = updated.(Model)
+	if m.retry == nil || !strings.HasSuffix(m.errMsg, "(R to retry)") {
+		t.Fatalf("errMsg = %q, a network error should offer a retry", m.errMsg)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(Model)
+	if cmd == nil || !m.loading || m.errMsg != "" || m.retry != nil {
+		t.Errorf("retry: loading = %v, errMsg = %q, cmd = %v", m.loading, m.errMsg, cmd)
+	}
+
+	failed.err = &radio.StatusError{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}
+	updated, _ = m.Update(failed)
+	m = updated.(Model)
+	if m.retry != nil || strings.Contains(m.errMsg, "retry") {
+		t.Errorf("errMsg = %q, a client error should not offer a retry", m.errMsg)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}); cmd != nil {
+		t.Error("R should do nothing without a pending retry")
+	}
+}
+
+func TestModel_RetryClearedBySuccess(t *testing.T) {
+	m := *createTestModel()
+	station := m.stations[0]
+	updated, _ := m.Update(playMsg{station: station, err: &radio.TimeoutError{Err: context.DeadlineExceeded}})
+	m = updated.(Model)
+	if m.retry == nil {
+		t.Fatalf("errMsg = %q, a timeout should offer a retry", m.errMsg)
+	}
+
+	updated, _ = m.Update(stationsMsg{stations: m.stations, source: m.stationSource, country: m.country, query: m.activeQueryKey()})
+	m = updated.(Model)
+	if m.retry != nil {
+		t.Error("a successful load should drop the pending retry")
+	}
+}
@@ -0,0 +1,335 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/radio"
+)
+
+type filterField int
+
+const (
+	filterName filterField = iota
+	filterCountry
+	filterState
+	filterTags
+	filterLanguage
+	filterCodec
+	filterMinBitrate
+	filterMaxBitrate
+	filterHasGeo
+	filterOrder
+	filterDirection
+	filterFieldCount
+
+	// filterTextFields is the number of leading fields edited as text.
+	filterTextFields = filterHasGeo
+)
+
+var filterLabels = [filterFieldCount]string{
+	filterName:       "Name",
+	filterCountry:    "Country",
+	filterState:      "State",
+	filterTags:       "Tags",
+	filterLanguage:   "Language",
+	filterCodec:      "Codec",
+	filterMinBitrate: "Min kbps",
+	filterMaxBitrate: "Max kbps",
+	filterHasGeo:     "Has geo",
+	filterOrder:      "Order by",
+	filterDirection:  "Direction",
+}
+
+// filterForm is the overlay used to compose a radio.SearchParams.
+type filterForm struct {
+	inputs    []textinput.Model
+	hasGeo    bool
+	order     int
+	ascending bool
+	focus     filterField
+	err       string
+}
+
+func newFilterForm() filterForm {
+	form := filterForm{inputs: make([]textinput.Model, filterTextFields)}
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = 24
+		form.inputs[i] = input
+	}
+	form.inputs[filterCountry].CharLimit = 2
+	form.inputs[filterCountry].Placeholder = "any"
+	form.inputs[filterTags].Placeholder = "rock, indie"
+	form.inputs[filterMinBitrate].CharLimit = 4
+	form.inputs[filterMaxBitrate].CharLimit = 4
+	return form
+}
+
+// load fills the form from params and focuses the first field.
+func (f *filterForm) load(params radio.SearchParams) {
+	f.inputs[filterName].SetValue(params.Name)
+	f.inputs[filterCountry].SetValue(params.CountryCode)
+	f.inputs[filterState].SetValue(params.State)
+	f.inputs[filterTags].SetValue(strings.Join(params.Tags, ", "))
+	f.inputs[filterLanguage].SetValue(params.Language)
+	f.inputs[filterCodec].SetValue(params.Codec)
+	f.inputs[filterMinBitrate].SetValue(bitrateText(params.MinBitrate))
+	f.inputs[filterMaxBitrate].SetValue(bitrateText(params.MaxBitrate))
+	f.hasGeo = params.HasGeo
+	f.order = 0
+	for i, order := range radio.SearchOrders {
+		if order == params.Order {
+			f.order = i
+		}
+	}
+	f.ascending = params.Ascending
+	f.err = ""
+	f.setFocus(filterName)
+}
+
+// params builds search parameters from the form, validating bitrates.
+func (f filterForm) params() (radio.SearchParams, error) {
+	minBitrate, err := parseBitrate(f.inputs[filterMinBitrate].Value())
+	if err != nil {
+		return radio.SearchParams{}, fmt.Errorf("min kbps: %w", err)
+	}
+	maxBitrate, err := parseBitrate(f.inputs[filterMaxBitrate].Value())
+	if err != nil {
+		return radio.SearchParams{}, fmt.Errorf("max kbps: %w", err)
+	}
+
+	params := radio.SearchParams{
+		Name:        strings.TrimSpace(f.inputs[filterName].Value()),
+		CountryCode: strings.ToUpper(strings.TrimSpace(f.inputs[filterCountry].Value())),
+		State:       strings.TrimSpace(f.inputs[filterState].Value()),
+		Tags:        radio.ParseTags(f.inputs[filterTags].Value()),
+		Language:    strings.TrimSpace(f.inputs[filterLanguage].Value()),
+		Codec:       strings.TrimSpace(f.inputs[filterCodec].Value()),
+		MinBitrate:  minBitrate,
+		MaxBitrate:  maxBitrate,
+		HasGeo:      f.hasGeo,
+		Order:       radio.SearchOrders[f.order],
+		Ascending:   f.ascending,
+		Limit:       1,
+	}
+	if _, err := params.Values(); err != nil {
+		return radio.SearchParams{}, err
+	}
+	params.Limit = 0
+	return params, nil
+}
+
+func (f *filterForm) setFocus(field filterField) {
+	f.focus = (field + filterFieldCount) % filterFieldCount
+	for i := range f.inputs {
+		if filterField(i) == f.focus {
+			f.inputs[i].Focus()
+			f.inputs[i].CursorEnd()
+		} else {
+			f.inputs[i].Blur()
+		}
+	}
+}
+
+func (f *filterForm) blur() {
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+}
+
+// cycle changes the value of a toggle field by delta.
+func (f *filterForm) cycle(delta int) {
+	switch f.focus {
+	case filterHasGeo:
+		f.hasGeo = !f.hasGeo
+	case filterOrder:
+		count := len(radio.SearchOrders)
+		f.order = ((f.order+delta)%count + count) % count
+	case filterDirection:
+		f.ascending = !f.ascending
+	}
+}
+
+func (m Model) openFilterForm() (tea.Model, tea.Cmd) {
+	params := m.activeFilter
+	if m.stationSource != sourceFilter {
+		params = radio.SearchParams{CountryCode: m.country}
+	}
+	if m.filter.inputs == nil {
+		m.filter = newFilterForm()
+	}
+	m.filter.load(params)
+	m.inputMode = inputFilter
+	return m, textinput.Blink
+}
+
+func (m Model) updateFilterForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "esc":
+		m.inputMode = inputNone
+		m.filter.blur()
+		return m, nil
+	case "tab", "down":
+		m.filter.setFocus(m.filter.focus + 1)
+		return m, nil
+	case "shift+tab", "up":
+		m.filter.setFocus(m.filter.focus - 1)
+		return m, nil
+	case "enter":
+		params, err := m.filter.params()
+		if err != nil {
+			m.filter.err = err.Error()
+			return m, nil
+		}
+		m.inputMode = inputNone
+		m.filter.blur()
+		m.activeFilter = params
+		m.stationSource = sourceFilter
+		m.activeSearch = ""
+		m.search.SetValue("")
+		m.page = 0
+		m.hasMore = false
+		m.selected = 0
+		m.loading = true
+		m.errMsg = ""
+		return m, m.loadStationsCmd()
+	}
+
+	if m.filter.focus >= filterTextFields {
+		switch key.String() {
+		case "left":
+			m.filter.cycle(-1)
+		case "right", " ":
+			m.filter.cycle(1)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter.inputs[m.filter.focus], cmd = m.filter.inputs[m.filter.focus].Update(msg)
+	m.filter.err = ""
+	return m, cmd
+}
+
+func (m Model) renderFilterForm(width int) string {
+	panelWidth := min(max(width, 10), 56)
+	lines := []string{m.styles.ListHeader.Render("Station Filters"), ""}
+
+	for field := filterField(0); field < filterFieldCount; field++ {
+		marker := "  "
+		style := m.styles.ListItem
+		if field == m.filter.focus {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		label := style.Render(fmt.Sprintf("%s%-10s", marker, filterLabels[field]))
+		lines = append(lines, label+" "+m.filterFieldValue(field))
+	}
+
+	if m.filter.err != "" {
+		lines = append(lines, "", m.styles.Error.Render(m.filter.err))
+	}
+	lines = append(lines, "", m.styles.Muted.Render("Tab/Up/Down move  Left/Right change  Enter search  Esc cancel"))
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m Model) filterFieldValue(field filterField) string {
+	switch field {
+	case filterHasGeo:
+		if m.filter.hasGeo {
+			return "[x] only stations on the map"
+		}
+		return "[ ]"
+	case filterOrder:
+		return "< " + radio.SearchOrders[m.filter.order] + " >"
+	case filterDirection:
+		if m.filter.ascending {
+			return "Ascending"
+		}
+		return "Descending"
+	}
+	return m.filter.inputs[field].View()
+}
+
+// describeFilter summarises active filters for the list header.
+func describeFilter(params radio.SearchParams) string {
+	parts := []string{}
+	if params.Name != "" {
+		parts = append(parts, fmt.Sprintf("%q", params.Name))
+	}
+	if params.CountryCode != "" {
+		parts = append(parts, params.CountryCode)
+	}
+	if params.State != "" {
+		parts = append(parts, params.State)
+	}
+	if len(params.Tags) > 0 {
+		parts = append(parts, "#"+strings.Join(params.Tags, " #"))
+	}
+	if params.Language != "" {
+		parts = append(parts, params.Language)
+	}
+	if params.Codec != "" {
+		parts = append(parts, strings.ToUpper(params.Codec))
+	}
+	switch {
+	case params.MinBitrate > 0 && params.MaxBitrate > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d kbps", params.MinBitrate, params.MaxBitrate))
+	case params.MinBitrate > 0:
+		parts = append(parts, fmt.Sprintf(">=%d kbps", params.MinBitrate))
+	case params.MaxBitrate > 0:
+		parts = append(parts, fmt.Sprintf("<=%d kbps", params.MaxBitrate))
+	}
+	if params.HasGeo {
+		parts = append(parts, "geo")
+	}
+	direction := "desc"
+	if params.Ascending {
+		direction = "asc"
+	}
+	parts = append(parts, fmt.Sprintf("by %s %s", fallback(params.Order, "clickcount"), direction))
+	return strings.Join(parts, ", ")
+}
+
+// filterKey identifies a filter independently of paging, so stale results
+// from a previous filter can be discarded.
+func filterKey(params radio.SearchParams) string {
+	params.Limit = 1
+	params.Offset = 0
+	query, err := params.Values()
+	if err != nil {
+		return ""
+	}
+	return query.Encode()
+}
+
+func parseBitrate(text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%q is not a bitrate", text)
+	}
+	return value, nil
+}
+
+func bitrateText(value int) string {
+	if value <= 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+func typeText(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	return updated.(Model)
+}
+
+func pressKey(t *testing.T, m Model, key tea.KeyType) (Model, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(tea.KeyMsg{Type: key})
+	return updated.(Model), cmd
+}
+
+func TestFilterForm_RoundTrip(t *testing.T) {
+	want := radio.SearchParams{
+		Name:        "jazz",
+		CountryCode: "DE",
+		State:       "Berlin",
+		Tags:        []string{"smooth", "late night"},
+		Language:    "german",
+		Codec:       "AAC",
+		MinBitrate:  96,
+		MaxBitrate:  320,
+		HasGeo:      true,
+		Order:       "votes",
+		Ascending:   true,
+	}
+
+	form := newFilterForm()
+	form.load(want)
+	got, err := form.params()
+	if err != nil {
+		t.Fatalf("params() error = %v", err)
+	}
+	if filterKey(got) != filterKey(want) {
+		t.Errorf("params() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFilterForm_InvalidBitrate(t *testing.T) {
+	m := *createTestModel()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(Model)
+	if m.inputMode != inputFilter {
+		t.Fatal("s should open the filter form")
+	}
+
+	for m.filter.focus != filterMinBitrate {
+		m, _ = pressKey(t, m, tea.KeyTab)
+	}
+	m = typeText(t, m, "fast")
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if cmd != nil || m.inputMode != inputFilter {
+		t.Error("invalid form should stay open")
+	}
+	if !strings.Contains(m.filter.err, "min kbps") {
+		t.Errorf("filter.err = %q", m.filter.err)
+	}
+}
+
+func TestFilterForm_AppliesSearch(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries []url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Write([]byte(`[{"stationuuid":"x","name":"Filtered FM"}]`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(Model)
+	for m.filter.focus != filterTags {
+		m, _ = pressKey(t, m, tea.KeyTab)
+	}
+	m = typeText(t, m, "rock, indie")
+	for m.filter.focus != filterOrder {
+		m, _ = pressKey(t, m, tea.KeyTab)
+	}
+	m, _ = pressKey(t, m, tea.KeyRight)
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if m.inputMode != inputNone || m.stationSource != sourceFilter || !m.loading {
+		t.Fatalf("mode/source/loading = %v/%v/%v", m.inputMode, m.stationSource, m.loading)
+	}
+	if cmd == nil {
+		t.Fatal("applying filters should load stations")
+	}
+
+	msg := cmd().(stationsMsg)
+	if len(queries) != 1 {
+		t.Fatalf("requests = %d, want 1", len(queries))
+	}
+	query := queries[0]
+	if query.Get("tagList") != "rock,indie" || query.Get("countrycodeexact") != "US" || query.Get("order") != radio.SearchOrders[1] {
+		t.Errorf("query = %v", query)
+	}
+
+	// Results for a different filter are ignored.
+	stale := msg
+	stale.query = "stale"
+	updated, _ = m.Update(stale)
+	if !updated.(Model).loading {
+		t.Error("results for another filter should be discarded")
+	}
+
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if m.loading || len(m.stations) != 1 || m.stations[0].Name != "Filtered FM" {
+		t.Errorf("stations = %+v, loading = %v", m.stations, m.loading)
+	}
+	if header := m.renderList(80, 5); !strings.Contains(header, "#rock #indie") {
+		t.Errorf("list header should describe the filter, got %q", header)
+	}
+}
+
+func TestDescribeFilter(t *testing.T) {
+	got := describeFilter(radio.SearchParams{Tags: []string{"rock"}, MinBitrate: 128, Order: "votes"})
+	if got != "#rock, >=128 kbps, by votes desc" {
+		t.Errorf("describeFilter() = %q", got)
+	}
+}
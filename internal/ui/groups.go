@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+func newGroupInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Group: "
+	input.Placeholder = "News, Jazz, ... (empty to ungroup)"
+	input.CharLimit = 40
+	input.Width = 26
+	return input
+}
+
+// openFavorites shows every favorite, or steps to the next group when the
+// favorites are already showing.
+func (m Model) openFavorites() (tea.Model, tea.Cmd) {
+	if m.favorites == nil || m.favorites.Count() == 0 {
+		m.errMsg = "No favorites saved yet"
+		return m, nil
+	}
+	if m.isFavoritesSource() {
+		return m.cycleFavoriteGroup(1)
+	}
+	m.stationSource = sourceFavorites
+	m.favGroup = ""
+	return m.reloadFavorites()
+}
+
+// cycleFavoriteGroup steps through "all favorites" and the named groups.
+func (m Model) cycleFavoriteGroup(step int) (tea.Model, tea.Cmd) {
+	if !m.isFavoritesSource() || m.favorites == nil {
+		return m, nil
+	}
+	groups := append([]string{""}, m.favorites.Groups()...)
+	if len(groups) == 1 {
+		m.errMsg = "No favorite groups yet; press M to file a station under one"
+		return m, nil
+	}
+	i := slices.Index(groups, m.favGroup)
+	m.favGroup = groups[(i+step+len(groups))%len(groups)]
+	return m.reloadFavorites()
+}
+
+func (m Model) reloadFavorites() (tea.Model, tea.Cmd) {
+	m.activeSearch = ""
+	m.search.SetValue("")
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	m.errMsg = ""
+	return m, m.loadStationsCmd()
+}
+
+// favoritesTitle names the favorites list, including the group shown.
+func (m Model) favoritesTitle() string {
+	if m.favGroup == "" {
+		return "Favorites"
+	}
+	return "Favorites: " + m.favGroup
+}
+
+// moveFavorite swaps the selected favorite with its neighbour, saving the
+// new order, and keeps it selected.
+func (m Model) moveFavorite(step int) (tea.Model, tea.Cmd) {
+	if !m.isFavoritesSource() || m.favorites == nil {
+		m.errMsg = "Open favorites (V) to reorder them"
+		return m, nil
+	}
+	if m.activeSearch != "" {
+		// Search results are not neighbours in the saved order.
+		m.errMsg = "Clear the search to reorder favorites"
+		return m, nil
+	}
+	target := m.selected + step
+	if m.loading || m.selected >= len(m.stations) || target < 0 || target >= len(m.stations) {
+		return m, nil
+	}
+	current, neighbour := m.stations[m.selected], m.stations[target]
+	if err := m.favorites.Swap(current.UUID, neighbour.UUID); err != nil {
+		m.errMsg = err.Error()
+		return m, nil
+	}
+	stations := append([]radio.Station(nil), m.stations...)
+	stations[m.selected], stations[target] = neighbour, current
+	m.stations = stations
+	m.updateDialRange()
+	m.moveSelection(step)
+	return m, m.dialTickCmd()
+}
+
+func (m Model) openGroupInput() (tea.Model, tea.Cmd) {
+	station, ok := m.currentStation()
+	if !ok {
+		return m, nil
+	}
+	if m.favorites == nil {
+		return m, nil
+	}
+	fav, ok := m.favorites.Get(station.UUID)
+	if !ok {
+		m.errMsg = "Favorite the station (F) before filing it under a group"
+		return m, nil
+	}
+	if m.groupInput.Prompt == "" {
+		m.groupInput = newGroupInput()
+	}
+	m.groupInput.SetValue(fav.Group)
+	m.groupInput.Focus()
+	m.groupInput.CursorEnd()
+	m.inputMode = inputGroup
+	return m, textinput.Blink
+}
+
+func (m Model) updateGroupInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "esc":
+			m.inputMode = inputNone
+			m.groupInput.Blur()
+			return m, nil
+		case "tab":
+			// Complete to the next existing group.
+			groups := m.favorites.Groups()
+			if len(groups) > 0 {
+				i := slices.Index(groups, strings.TrimSpace(m.groupInput.Value()))
+				m.groupInput.SetValue(groups[(i+1)%len(groups)])
+				m.groupInput.CursorEnd()
+			}
+			return m, nil
+		case "enter":
+			m.inputMode = inputNone
+			m.groupInput.Blur()
+			return m.setFavoriteGroup(strings.TrimSpace(m.groupInput.Value()))
+		}
+	}
+
+	var cmd tea.Cmd
+	m.groupInput, cmd = m.groupInput.Update(msg)
+	return m, cmd
+}
+
+func (m Model) setFavoriteGroup(group string) (tea.Model, tea.Cmd) {
+	station, ok := m.currentStation()
+	if !ok {
+		return m, nil
+	}
+	if err := m.favorites.SetGroup(station.UUID, group); err != nil {
+		m.errMsg = err.Error()
+		return m, nil
+	}
+	if group == "" {
+		m.errMsg = fmt.Sprintf("Removed %s from its group", station.Name)
+	} else {
+		m.errMsg = fmt.Sprintf("Filed %s under %s", station.Name, group)
+	}
+	if !m.isFavoritesSource() || m.favGroup == "" || m.favGroup == group {
+		return m, nil
+	}
+
+	// The station left the group on screen.
+	if !slices.Contains(m.favorites.Groups(), m.favGroup) {
+		m.favGroup = ""
+	}
+	status := m.errMsg
+	updated, cmd := m.reloadFavorites()
+	next := updated.(Model)
+	next.errMsg = status
+	return next, cmd
+}
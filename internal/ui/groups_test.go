@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func newFavoritesTestModel(t *testing.T, stations ...radio.Station) Model {
+	t.Helper()
+	useTempConfigDir(t)
+	favorites, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	for _, station := range stations {
+		if _, err := favorites.Toggle(station); err != nil {
+			t.Fatalf("Toggle() error = %v", err)
+		}
+	}
+	m := *createTestModel()
+	m.favorites = favorites
+	m.stationSource = sourceFavorites
+	m.stations = favoritesToStations(favorites.List())
+	return m
+}
+
+func favoriteNames(favs []config.Favorite) string {
+	names := make([]string, 0, len(favs))
+	for _, fav := range favs {
+		names = append(names, fav.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestFavorites_Reorder(t *testing.T) {
+	m := newFavoritesTestModel(t,
+		radio.Station{UUID: "1", Name: "Rock FM"},
+		radio.Station{UUID: "2", Name: "Pop Radio"},
+		radio.Station{UUID: "3", Name: "Jazz Station"},
+	)
+
+	m = typeText(t, m, ">")
+	if m.selected != 1 || m.stations[1].UUID != "1" {
+		t.Fatalf("selected = %d, stations = %+v; the favorite should move down with the selection", m.selected, m.stations)
+	}
+	m, _ = pressKey(t, m, tea.KeyShiftDown)
+	m = typeText(t, m, "<")
+	if got := favoriteNames(m.favorites.List()); got != "Pop Radio,Rock FM,Jazz Station" {
+		t.Errorf("saved order = %s", got)
+	}
+
+	// Moving past the end of the list does nothing.
+	m.selected = 2
+	m, _ = pressKey(t, m, tea.KeyShiftDown)
+	if m.selected != 2 {
+		t.Errorf("selected = %d", m.selected)
+	}
+
+	m.stationSource = sourceCountry
+	m = typeText(t, m, "<")
+	if !strings.Contains(m.errMsg, "Open favorites") {
+		t.Errorf("errMsg = %q; reordering needs the favorites list", m.errMsg)
+	}
+}
+
+func TestFavorites_ReorderNeedsFullList(t *testing.T) {
+	m := newFavoritesTestModel(t,
+		radio.Station{UUID: "1", Name: "Rock FM"},
+		radio.Station{UUID: "2", Name: "Pop Radio"},
+		radio.Station{UUID: "3", Name: "Rock Classics"},
+	)
+	// With a search the two rock stations are listed next to each other.
+	m.activeSearch = "rock"
+	m.stations = []radio.Station{m.stations[0], m.stations[2]}
+
+	m = typeText(t, m, ">")
+	if !strings.Contains(m.errMsg, "Clear the search") {
+		t.Errorf("errMsg = %q; reordering search results should be refused", m.errMsg)
+	}
+	if got := favoriteNames(m.favorites.List()); got != "Rock FM,Pop Radio,Rock Classics" {
+		t.Errorf("saved order = %s", got)
+	}
+}
+
+func TestFavorites_Groups(t *testing.T) {
+	m := newFavoritesTestModel(t,
+		radio.Station{UUID: "1", Name: "Rock FM"},
+		radio.Station{UUID: "2", Name: "Jazz Station"},
+	)
+	m = typeText(t, m, "v")
+	if !strings.Contains(m.errMsg, "No favorite groups yet") {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+
+	m.selected = 1
+	m = typeText(t, m, "m")
+	if m.inputMode != inputGroup {
+		t.Fatalf("mode = %v, want the group prompt", m.inputMode)
+	}
+	m = typeText(t, m, "Jazz")
+	m, _ = pressKey(t, m, tea.KeyEnter)
+	if m.inputMode != inputNone || m.errMsg != "Filed Jazz Station under Jazz" {
+		t.Fatalf("mode = %v, errMsg = %q", m.inputMode, m.errMsg)
+	}
+
+	// V again switches to the group.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = updated.(Model)
+	if m.favGroup != "Jazz" || !m.loading {
+		t.Fatalf("group = %q, loading = %v", m.favGroup, m.loading)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.stations) != 1 || m.stations[0].UUID != "2" {
+		t.Fatalf("stations = %+v, want only the Jazz group", m.stations)
+	}
+	if view := m.renderList(60, 5); !strings.Contains(view, "Favorites: Jazz") {
+		t.Errorf("list header should name the group:\n%s", view)
+	}
+
+	// Ungrouping the last member falls back to all favorites.
+	m = typeText(t, m, "m")
+	m.groupInput.SetValue("")
+	m, cmd = pressKey(t, m, tea.KeyEnter)
+	if m.favGroup != "" || cmd == nil || m.errMsg != "Removed Jazz Station from its group" {
+		t.Fatalf("group = %q, errMsg = %q", m.favGroup, m.errMsg)
+	}
+	updated, _ = m.Update(cmd())
+	if got := len(updated.(Model).stations); got != 2 {
+		t.Errorf("stations = %d, want all favorites", got)
+	}
+}
+
+func TestFavorites_GroupNeedsFavorite(t *testing.T) {
+	useTempConfigDir(t)
+	favorites, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	m := *createTestModel()
+	m.favorites = favorites
+
+	m = typeText(t, m, "M")
+	if m.inputMode != inputNone || !strings.Contains(m.errMsg, "Favorite the station") {
+		t.Errorf("mode = %v, errMsg = %q", m.inputMode, m.errMsg)
+	}
+}
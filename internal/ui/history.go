@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/player"
+	"radio-tui/internal/radio"
+)
+
+// recentLimit is how many stations the recently played list offers.
+const recentLimit = 10
+
+// historyOrder sorts the history list.
+type historyOrder int
+
+const (
+	historyRecent historyOrder = iota
+	historyMostListened
+)
+
+func (o historyOrder) title() string {
+	if o == historyMostListened {
+		return "History: most listened"
+	}
+	return "History: recently played"
+}
+
+// recentPicker is the quick list of recently played stations.
+type recentPicker struct {
+	stations []radio.Station
+	index    int
+}
+
+// titlePollInterval is how often the playing backend is asked for the
+// stream title.
+const titlePollInterval = 2 * time.Second
+
+type titleMsg struct {
+	uuid  string
+	title string
+	ctx   context.Context
+}
+
+type historyClearedMsg struct{ err error }
+
+// openHistory shows the listening history, or switches its order when it
+// is already showing.
+func (m Model) openHistory() (tea.Model, tea.Cmd) {
+	if m.history == nil {
+		m.errMsg = "Listening history is off (disable_history in config.json)"
+		return m, nil
+	}
+	if m.stationSource == sourceHistory {
+		m.historyOrder = (m.historyOrder + 1) % 2
+	} else {
+		if m.history.Count() == 0 {
+			m.errMsg = "Nothing played yet"
+			return m, nil
+		}
+		m.stationSource = sourceHistory
+		m.historyOrder = historyRecent
+	}
+	m.activeSearch = ""
+	m.search.SetValue("")
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	m.errMsg = ""
+	return m, m.loadStationsCmd()
+}
+
+// historyStations lists the stations in the history in the given order.
+func historyStations(history *config.History, order historyOrder, now time.Time) []radio.Station {
+	if history == nil {
+		return []radio.Station{}
+	}
+	if order == historyRecent {
+		return history.Recent(0)
+	}
+	stats := history.Stats(now)
+	stations := make([]radio.Station, 0, len(stats))
+	for _, s := range stats {
+		stations = append(stations, s.Station)
+	}
+	return stations
+}
+
+// historyTotals maps each station in the history to its statistics.
+func (m Model) historyTotals() map[string]config.StationStats {
+	if m.history == nil {
+		return nil
+	}
+	totals := map[string]config.StationStats{}
+	for _, stats := range m.history.Stats(time.Now()) {
+		totals[stats.Station.UUID] = stats
+	}
+	return totals
+}
+
+func (m Model) openRecent() (tea.Model, tea.Cmd) {
+	if m.history == nil {
+		m.errMsg = "Listening history is off (disable_history in config.json)"
+		return m, nil
+	}
+	stations := m.history.Recent(recentLimit)
+	if len(stations) == 0 {
+		m.errMsg = "Nothing played yet"
+		return m, nil
+	}
+	m.recent = recentPicker{stations: stations}
+	m.inputMode = inputRecent
+	return m, nil
+}
+
+func (m Model) updateRecentPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "up", "k":
+		m.recent.index = max(m.recent.index-1, 0)
+	case "down", "j":
+		m.recent.index = min(m.recent.index+1, len(m.recent.stations)-1)
+	case "enter":
+		return m.playRecent(m.recent.index)
+	case "esc", "b", "B":
+		m.inputMode = inputNone
+	default:
+		// 1-9 and 0 pick the rows as numbered.
+		if n, ok := presetKey(key.String()); ok {
+			return m.playRecent((n + recentLimit - 1) % recentLimit)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) playRecent(index int) (tea.Model, tea.Cmd) {
+	if index < 0 || index >= len(m.recent.stations) {
+		return m, nil
+	}
+	station := m.recent.stations[index]
+	m.inputMode = inputNone
+	for i, listed := range m.visibleStations() {
+		if listed.UUID == station.UUID {
+			m.selected = i
+			m.dialTarget = m.dialValueForIndex(i)
+			break
+		}
+	}
+	m.errMsg = ""
+	return m, tea.Batch(m.dialTickCmd(), m.playStationCmd(station))
+}
+
+func (m Model) renderRecentPicker(width int) string {
+	panelWidth := min(max(width, 10), 56)
+	lines := []string{m.styles.ListHeader.Render("Recently Played"), ""}
+
+	totals := m.historyTotals()
+	nameWidth := max(panelWidth-24, 8)
+	for i, station := range m.recent.stations {
+		marker := "  "
+		style := m.styles.ListItem
+		if i == m.recent.index {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		stats := totals[station.UUID]
+		line := fmt.Sprintf("%s%d %-*s %7s  %s", marker, (i+1)%recentLimit, nameWidth, truncateText(station.Name, nameWidth),
+			formatListened(stats.Total), formatPlayed(stats.LastPlayed, time.Now()))
+		lines = append(lines, style.Render(line))
+	}
+	lines = append(lines, "", m.styles.Muted.Render("Up/Down choose  1-0/Enter play  Esc close"))
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// clearHistory forgets the listening history. It asks first: the history
+// is only cleared when D is pressed twice in a row.
+func (m Model) clearHistory(confirmed bool) (tea.Model, tea.Cmd) {
+	if m.history == nil {
+		m.errMsg = "Listening history is off (disable_history in config.json)"
+		return m, nil
+	}
+	if !confirmed {
+		m.confirmClear = true
+		m.errMsg = fmt.Sprintf("Press D again to clear the listening history (%d sessions)", m.history.Count())
+		return m, nil
+	}
+	history := m.history
+	return m, func() tea.Msg {
+		return historyClearedMsg{err: history.Clear()}
+	}
+}
+
+func (m Model) updateHistoryCleared(msg historyClearedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.errMsg = "Failed to clear history: " + msg.err.Error()
+		return m, nil
+	}
+	m.errMsg = "Listening history cleared"
+	if m.stationSource != sourceHistory {
+		return m, nil
+	}
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	return m, m.loadStationsCmd()
+}
+
+// recordPlay starts a history session for station and watches the player
+// for stream titles.
+func (m *Model) recordPlay(station radio.Station) tea.Cmd {
+	m.nowTitle = ""
+	if m.history == nil {
+		return nil
+	}
+	if err := m.history.Start(station, time.Now()); err != nil {
+		m.errMsg = "Failed to save listening history: " + err.Error()
+	}
+	return m.watchTitlesCmd(station.UUID)
+}
+
+// stopPlayback stops the player and ends the history session.
+func (m *Model) stopPlayback() {
+	if m.player != nil {
+		_ = m.player.Stop()
+	}
+	m.playing = false
+	m.nowTitle = ""
+	m.titleWatch.stop()
+	if m.history != nil {
+		if err := m.history.Stop(time.Now()); err != nil {
+			m.errMsg = "Failed to save listening history: " + err.Error()
+		}
+	}
+}
+
+// watchTitlesCmd polls the player for the title of the station playing
+// now, replacing any previous watch. Backends that cannot read titles are
+// not polled.
+func (m Model) watchTitlesCmd(uuid string) tea.Cmd {
+	if _, ok := m.player.(player.TitledBackend); !ok {
+		return nil
+	}
+	return pollTitleCmd(m.player, uuid, m.titleWatch.next(), 0)
+}
+
+func pollTitleCmd(backend player.Backend, uuid string, ctx context.Context, delay time.Duration) tea.Cmd {
+	titled, ok := backend.(player.TitledBackend)
+	if !ok {
+		return nil
+	}
+	poll := func() tea.Msg {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return titleMsg{uuid: uuid, title: titled.Title(), ctx: ctx}
+	}
+	if delay == 0 {
+		return poll
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return poll() })
+}
+
+func (m Model) updateTitle(msg titleMsg) (tea.Model, tea.Cmd) {
+	if !m.playing || msg.uuid != m.playingUUID || msg.ctx.Err() != nil {
+		return m, nil
+	}
+	next := pollTitleCmd(m.player, msg.uuid, msg.ctx, titlePollInterval)
+	if msg.title == "" || msg.title == m.nowTitle {
+		return m, next
+	}
+	m.nowTitle = msg.title
+	if m.history != nil {
+		if err := m.history.Title(msg.uuid, msg.title, time.Now()); err != nil {
+			m.errMsg = "Failed to save listening history: " + err.Error()
+		}
+	}
+	return m, next
+}
+
+// historyMeta describes the station's listening history for the meta
+// panel.
+func (m Model) historyMeta(station radio.Station) []string {
+	var lines []string
+	if m.playing && station.UUID == m.playingUUID && m.nowTitle != "" {
+		lines = append(lines, "Now: "+m.nowTitle)
+	}
+	if m.history == nil {
+		return lines
+	}
+	stats, ok := m.history.StationStats(station.UUID, time.Now())
+	if !ok {
+		return lines
+	}
+	listened := fmt.Sprintf("Listened: %s in %d sessions, last %s", formatListened(stats.Total), stats.Sessions, formatPlayed(stats.LastPlayed, time.Now()))
+	if stats.Sessions == 1 {
+		listened = fmt.Sprintf("Listened: %s, %s", formatListened(stats.Total), formatPlayed(stats.LastPlayed, time.Now()))
+	}
+	lines = append(lines, listened)
+	if len(lines) == 1 && stats.LastTitle != "" {
+		lines = append(lines, "Last heard: "+stats.LastTitle)
+	}
+	return lines
+}
+
+// formatListened renders a listening time as "45m" or "2h05m".
+func formatListened(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// formatPlayed renders when a station was last played, relative to now for
+// the last day.
+func formatPlayed(at, now time.Time) string {
+	switch {
+	case at.IsZero():
+		return "-"
+	case now.Sub(at) < time.Minute:
+		return "just now"
+	case now.Sub(at) < time.Hour:
+		return fmt.Sprintf("%dm ago", int(now.Sub(at)/time.Minute))
+	case now.Sub(at) < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(now.Sub(at)/time.Hour))
+	}
+	return at.Format("Jan 2")
+}
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/generators"
+	"github.com/gopxl/beep/v2/wav"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/player"
+	"radio-tui/internal/radio"
+)
+
+func newHistoryTestModel(t *testing.T) Model {
+	t.Helper()
+	useTempConfigDir(t)
+	history, err := config.LoadHistory(config.HistoryOptions{})
+	if err != nil {
+		t.Fatalf("LoadHistory() error = %v", err)
+	}
+	m := createTestModel()
+	m.history = history
+	m.titleWatch = &loadScope{}
+	m.stationLoads = &loadScope{}
+	return *m
+}
+
+// icyServer serves silent WAV audio with ICY metadata announcing one title
+// interleaved every metaInt bytes.
+func icyServer(t *testing.T, title string) *httptest.Server {
+	t.Helper()
+	const metaInt = 256
+	format := beep.Format{SampleRate: 22050, NumChannels: 2, Precision: 2}
+	var audio bytes.Buffer
+	path := filepath.Join(t.TempDir(), "silence.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := wav.Encode(f, generators.Silence(1024), format); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	f.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+
+	meta := []byte("StreamTitle='" + title + "';")
+	size := (len(meta) + 15) / 16
+	for start := 0; start < len(data); start += metaInt {
+		audio.Write(data[start:min(start+metaInt, len(data))])
+		if start == 0 {
+			audio.WriteByte(byte(size))
+			audio.Write(append(meta, make([]byte, size*16-len(meta))...))
+		} else {
+			audio.WriteByte(0)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		if r.Header.Get("Icy-MetaData") != "1" {
+			w.Write(data)
+			return
+		}
+		w.Header().Set("icy-metaint", strconv.Itoa(metaInt))
+		w.Write(audio.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestModel_HistoryRecordsPlayback(t *testing.T) {
+	m := newHistoryTestModel(t)
+	backend, err := player.NewWithOptions(player.Options{Priority: []string{"null"}})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	m.player = backend
+	defer backend.Stop()
+	server := icyServer(t, "Band - Song")
+
+	updated, cmd := m.Update(playMsg{station: m.stations[2], url: server.URL + "/live"})
+	m = updated.(Model)
+	if m.history.Count() != 1 || cmd == nil {
+		t.Fatalf("Count() = %d, errMsg = %q; want a session and a title watch", m.history.Count(), m.errMsg)
+	}
+	// The title comes from the stream the player already has open.
+	deadline := time.Now().Add(5 * time.Second)
+	for backend.(player.TitledBackend).Title() == "" && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	msg, ok := cmd().(titleMsg)
+	if !ok || msg.title != "Band - Song" || msg.uuid != "3" {
+		t.Fatalf("title watch returned %#v", msg)
+	}
+	updated, cmd = m.Update(msg)
+	m = updated.(Model)
+	if m.nowTitle != "Band - Song" || cmd == nil {
+		t.Errorf("nowTitle = %q, cmd = %v; want the title and the next poll", m.nowTitle, cmd)
+	}
+	// A title from a station no longer playing is ignored.
+	updated, _ = m.Update(titleMsg{uuid: "1", title: "Stale", ctx: msg.ctx})
+	m = updated.(Model)
+	if m.nowTitle != "Band - Song" {
+		t.Errorf("nowTitle = %q after stale title", m.nowTitle)
+	}
+
+	m.selected = 2
+	if meta := m.renderStationMeta(); !strings.Contains(meta, "Now: Band - Song") || !strings.Contains(meta, "Listened:") {
+		t.Errorf("meta panel lacks history:\n%s", meta)
+	}
+
+	m, _ = pressKey(t, m, tea.KeySpace)
+	if m.playing || m.nowTitle != "" {
+		t.Error("Space should stop playback and forget the title")
+	}
+	sessions := m.history.Sessions()
+	if len(sessions) != 1 || sessions[0].Stop.IsZero() || len(sessions[0].Titles) != 1 {
+		t.Errorf("Sessions() = %+v", sessions)
+	}
+}
+
+func TestModel_HistoryView(t *testing.T) {
+	m := newHistoryTestModel(t)
+	now := time.Now()
+	// Jazz played long ago for an hour, News just now for a minute.
+	jazz, news := m.stations[2], m.stations[3]
+	for _, play := range []struct {
+		station radio.Station
+		start   time.Duration
+		length  time.Duration
+	}{{jazz, -3 * time.Hour, time.Hour}, {news, -2 * time.Minute, time.Minute}} {
+		if err := m.history.Start(play.station, now.Add(play.start)); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.history.Stop(now.Add(play.start + play.length)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = updated.(Model)
+	if m.stationSource != sourceHistory || cmd == nil {
+		t.Fatalf("H should open the history, source = %v", m.stationSource)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if len(m.stations) != 2 || m.stations[0].UUID != news.UUID {
+		t.Fatalf("recent history = %+v", m.stations)
+	}
+	if list := m.renderList(80, 10); !strings.Contains(list, "History: recently played") || !strings.Contains(list, "1h00m") {
+		t.Errorf("history list:\n%s", list)
+	}
+
+	// Pressing H again sorts by time listened.
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.historyOrder != historyMostListened || len(m.stations) != 2 || m.stations[0].UUID != jazz.UUID {
+		t.Errorf("most listened history = %+v", m.stations)
+	}
+}
+
+func TestModel_RecentQuickList(t *testing.T) {
+	m := newHistoryTestModel(t)
+	now := time.Now()
+	for i, station := range m.stations[:3] {
+		if err := m.history.Start(station, now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m = typeText(t, m, "b")
+	if m.inputMode != inputRecent || len(m.recent.stations) != 3 || m.recent.stations[0].UUID != "3" {
+		t.Fatalf("recent list = %+v", m.recent.stations)
+	}
+	if view := m.renderRecentPicker(60); !strings.Contains(view, "1 Jazz Station") {
+		t.Errorf("recent list view:\n%s", view)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	m = updated.(Model)
+	if m.inputMode != inputNone || cmd == nil {
+		t.Fatal("2 should play the second recent station")
+	}
+	if m.selected != 1 {
+		t.Errorf("selected = %d, want the listed Pop Radio", m.selected)
+	}
+}
+
+func TestModel_ClearHistoryAsksFirst(t *testing.T) {
+	m := newHistoryTestModel(t)
+	if err := m.history.Start(m.stations[0], time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	m = typeText(t, m, "d")
+	if !strings.Contains(m.errMsg, "Press D again") {
+		t.Fatalf("errMsg = %q, want a confirmation prompt", m.errMsg)
+	}
+	// Any other key cancels.
+	m, _ = pressKey(t, m, tea.KeyDown)
+	m = typeText(t, m, "d")
+	if m.history.Count() != 1 {
+		t.Fatal("history was cleared without confirmation")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("second D should clear the history")
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.history.Count() != 0 || m.errMsg != "Listening history cleared" {
+		t.Errorf("Count() = %d, errMsg = %q", m.history.Count(), m.errMsg)
+	}
+}
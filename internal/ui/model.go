@@ -2,10 +2,12 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -25,22 +27,46 @@ const (
 	inputLocation
 	inputSearch
 	inputCountrySelect
-
-	stationPageSize = 200
+	inputFilter
+	inputBrowse
+	inputChart
+	inputNearby
+	inputAddStation
+	inputPlaylist
+	inputGroup
+	inputRecent
+
+	defaultPageSize = 200
+	// prefetchDistance is how close to the end of the list the selection
+	// gets before the next page is loaded in the background.
+	prefetchDistance = 20
 )
 
 const (
 	sourceCountry stationSource = iota
 	sourceFavorites
+	sourceFilter
+	sourceTag
+	sourceLanguage
+	sourceWorld
+	sourceCharts
+	sourceNearby
+	sourceHistory
 )
 
 type Model struct {
 	api       *radio.Client
 	player    player.Backend
 	favorites *config.Favorites
+	votes     *config.Votes
+	history   *config.History
+	cfg       config.AppConfig
 	styles    Styles
 	ipc       *ipcServer
 
+	// pendingVotes holds stations whose vote has been sent but not answered.
+	pendingVotes map[string]bool
+
 	stations []radio.Station
 	selected int
 
@@ -50,9 +76,48 @@ type Model struct {
 	country string
 	page    int
 	hasMore bool
+	// morePages counts pages after page appended by prefetching.
+	morePages   int
+	prefetching bool
+
+	// listCached and listStale describe whether the station list came from the
+	// on-disk API cache, and whether that was because the server was unreachable.
+	listCached bool
+	listStale  bool
 
 	stationSource stationSource
-	activeSearch string
+	activeSearch  string
+	activeFilter  radio.SearchParams
+	filter        filterForm
+	activeBrowse  string
+	browse        browseSelector
+	browseItems   [browseKindCount][]browseItem
+	activeChart   radio.Chart
+	chartCountry  string
+	chartPicker   chartPicker
+	stationLoads  *loadScope
+	nearby        *radio.Point
+	nearbyInput   textinput.Model
+	addForm       addStationForm
+	playlist      playlistForm
+	// favGroup is the favorites group shown, or empty for all favorites.
+	favGroup     string
+	groupInput   textinput.Model
+	historyOrder historyOrder
+	recent       recentPicker
+	// confirmClear is set by the first of the two D presses that clear the
+	// listening history.
+	confirmClear bool
+	// nowTitle is the stream title of the station playing, if it sends one.
+	nowTitle     string
+	titleWatch   *loadScope
+	checker      *player.Checker
+	checkStreams bool
+	streamChecks *loadScope
+	// playlistRuns cancels an import or export when its form is closed.
+	playlistRuns *loadScope
+	// retry repeats the request behind the last transient error.
+	retry retryFunc
 
 	inputMode     inputMode
 	location      textinput.Model
@@ -69,15 +134,21 @@ type Model struct {
 
 	playing           bool
 	playingUUID       string
+	playingBackend    string
 	lastStation       radio.Station
 	missingPlayer     bool
 	downloadingPlayer bool
+	downloadDone      int64
+	downloadTotal     int64
 
 	dialPos     float64
 	dialTarget  float64
 	dialMin     float64
 	dialMax     float64
 	dialUseFreq bool
+	// dialUseDistance scales the dial by distance in the nearby list when
+	// stations carry no FM frequency.
+	dialUseDistance bool
 
 	countries         []radio.Country
 	filteredCountries []radio.Country
@@ -91,8 +162,13 @@ type stationsMsg struct {
 	page     int
 	country  string
 	search   string
+	query    string
 	hasMore  bool
-	err      error
+	cached   bool
+	stale    bool
+	// more marks a prefetched page to append to the list.
+	more bool
+	err  error
 }
 
 type countriesMsg struct {
@@ -106,6 +182,11 @@ type playMsg struct {
 	err     error
 }
 
+type voteMsg struct {
+	station radio.Station
+	err     error
+}
+
 type dialTickMsg struct{}
 
 type playerDownloadMsg struct {
@@ -113,9 +194,20 @@ type playerDownloadMsg struct {
 	err  error
 }
 
+type playerDownloadProgressMsg struct {
+	done    int64
+	total   int64
+	updates <-chan tea.Msg
+}
+
 type themeSavedMsg struct{ err error }
 
-func NewModel(api *radio.Client, player player.Backend, favorites *config.Favorites, playerErr error, favErr error, themeName string) Model {
+type favoritesRefreshedMsg struct {
+	result config.RefreshResult
+	err    error
+}
+
+func NewModel(api *radio.Client, player player.Backend, favorites *config.Favorites, playerErr error, favErr error, cfg config.AppConfig) Model {
 	location := textinput.New()
 	location.Prompt = "Country: "
 	location.Placeholder = "US"
@@ -132,7 +224,7 @@ func NewModel(api *radio.Client, player player.Backend, favorites *config.Favori
 	countrySearch.Placeholder = "Type country or code"
 	countrySearch.Width = 26
 
-	theme := ThemeBySlug(themeName)
+	theme := ThemeBySlug(cfg.Theme)
 	themeIdx := 0
 	for i, t := range Themes {
 		if t.Slug == theme.Slug {
@@ -145,6 +237,7 @@ func NewModel(api *radio.Client, player player.Backend, favorites *config.Favori
 		api:           api,
 		player:        player,
 		favorites:     favorites,
+		cfg:           cfg,
 		styles:        BuildStyles(theme),
 		theme:         theme,
 		themeIdx:      themeIdx,
@@ -153,11 +246,26 @@ func NewModel(api *radio.Client, player player.Backend, favorites *config.Favori
 		location:      location,
 		search:        search,
 		countrySearch: countrySearch,
+		filter:        newFilterForm(),
+		stationLoads:  &loadScope{},
+		checker:       newStreamChecker(),
+		checkStreams:  cfg.CheckStreams,
+		streamChecks:  &loadScope{},
+		titleWatch:    &loadScope{},
+		playlistRuns:  &loadScope{},
 		loading:       true,
 	}
 	if favorites != nil && favorites.Count() > 0 {
 		m.stationSource = sourceFavorites
 	}
+	if votes, err := config.LoadVotes(); err == nil {
+		m.votes = votes
+	}
+	if !cfg.DisableHistory {
+		if history, err := config.LoadHistory(cfg.HistoryOptions()); err == nil {
+			m.history = history
+		}
+	}
 
 	if playerErr != nil {
 		m.missingPlayer = true
@@ -180,7 +288,7 @@ func NewModel(api *radio.Client, player player.Backend, favorites *config.Favori
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.loadStationsCmd(), m.startIPCCmd(), m.maybeDownloadPlayerCmd())
+	return tea.Batch(m.loadStationsCmd(), m.startIPCCmd(), m.maybeDownloadPlayerCmd(), m.refreshFavoritesCmd())
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -194,9 +302,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		key := msg.String()
 
 		if key == "ctrl+c" || key == "q" {
-			if m.player != nil {
-				_ = m.player.Stop()
-			}
+			m.stopPlayback()
 			if m.ipc != nil {
 				m.ipc.Close()
 			}
@@ -240,26 +346,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateSearchInput(msg)
 		case inputCountrySelect:
 			return m.updateCountrySelect(msg)
+		case inputFilter:
+			return m.updateFilterForm(msg)
+		case inputBrowse:
+			return m.updateBrowseSelect(msg)
+		case inputChart:
+			return m.updateChartPicker(msg)
+		case inputNearby:
+			return m.updateNearbyInput(msg)
+		case inputAddStation:
+			return m.updateAddStationForm(msg)
+		case inputPlaylist:
+			return m.updatePlaylistForm(msg)
+		case inputGroup:
+			return m.updateGroupInput(msg)
+		case inputRecent:
+			return m.updateRecentPicker(msg)
+		}
+
+		confirmClear := m.confirmClear
+		m.confirmClear = false
+
+		if n, ok := presetKey(key); ok {
+			return m.recallPreset(n)
+		}
+		if n, ok := presetShiftKeys[key]; ok {
+			return m.storePreset(n)
 		}
 
 		switch key {
 		case "?":
 			m.showHelp = true
-		case "left":
-			if m.moveSelection(-1) {
-				return m, m.dialTickCmd()
-			}
-		case "right":
-			if m.moveSelection(1) {
-				return m, m.dialTickCmd()
-			}
-		case "up":
+		case "left", "up":
 			if m.moveSelection(-1) {
-				return m, m.dialTickCmd()
+				return m.selectionMoved()
 			}
-		case "down":
+		case "right", "down":
 			if m.moveSelection(1) {
-				return m, m.dialTickCmd()
+				return m.selectionMoved()
 			}
 		case "]", "pgdown":
 			if m.loading {
@@ -269,7 +393,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.errMsg = "No more stations on the next page"
 				return m, nil
 			}
-			m.page++
+			m.page = m.nextPage()
+			m.morePages = 0
 			m.selected = 0
 			m.loading = true
 			m.errMsg = ""
@@ -283,6 +408,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.page--
+			m.morePages = 0
 			m.selected = 0
 			m.loading = true
 			m.errMsg = ""
@@ -294,10 +420,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case " ":
 			if m.playing {
-				if m.player != nil {
-					_ = m.player.Stop()
-				}
-				m.playing = false
+				m.stopPlayback()
 				return m, nil
 			}
 			if m.lastStation.UUID != "" {
@@ -317,19 +440,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.ensureCountrySelection()
 			return m, textinput.Blink
 		case "V", "v":
-			if m.favorites == nil || m.favorites.Count() == 0 {
-				m.errMsg = "No favorites saved yet"
-				return m, nil
-			}
-			m.stationSource = sourceFavorites
-			m.activeSearch = ""
-			m.search.SetValue("")
-			m.page = 0
-			m.hasMore = false
-			m.selected = 0
-			m.loading = true
-			m.errMsg = ""
-			return m, m.loadStationsCmd()
+			return m.openFavorites()
+		case "tab":
+			return m.cycleFavoriteGroup(1)
+		case "shift+tab":
+			return m.cycleFavoriteGroup(-1)
+		case "<", "shift+up":
+			return m.moveFavorite(-1)
+		case ">", "shift+down":
+			return m.moveFavorite(1)
+		case "m", "M":
+			return m.openGroupInput()
+		case "h", "H":
+			return m.openHistory()
+		case "b", "B":
+			return m.openRecent()
+		case "d", "D":
+			return m.clearHistory(confirmClear)
 		case "/":
 			m.inputMode = inputSearch
 			m.search.SetValue(m.activeSearch)
@@ -338,6 +465,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, textinput.Blink
 		case "t", "T":
 			m.showTheme = true
+		case "s", "S":
+			return m.openFilterForm()
+		case "w", "W":
+			return m.toggleWorldwide()
+		case "c", "C":
+			return m.openChartPicker()
+		case "a", "A":
+			return m.openNearbyInput()
+		case "i", "I":
+			return m.openAddStationForm()
+		case "e", "E":
+			return m.openPlaylistForm()
+		case "r", "R":
+			return m.retryLast()
+		case "x", "X":
+			return m.toggleStreamChecks()
+		case "g", "G":
+			return m.openBrowse(browseTag)
+		case "n", "N":
+			return m.openBrowse(browseLanguage)
+		case "+":
+			if station, ok := m.currentStation(); ok {
+				return m.voteStation(station)
+			}
 		case "f", "F":
 			if m.favorites != nil {
 				if station, ok := m.currentStation(); ok {
@@ -357,24 +508,77 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 	case stationsMsg:
-		if msg.source != m.stationSource || msg.page != m.page || msg.country != m.country || msg.search != m.activeSearch {
+		if msg.more {
+			return m.updateMoreStations(msg)
+		}
+		if msg.source != m.stationSource || msg.page != m.page || msg.country != m.country || msg.search != m.activeSearch || msg.query != m.activeQueryKey() {
+			return m, nil
+		}
+		if errors.Is(msg.err, context.Canceled) {
+			// Superseded by a newer load for the same list.
 			return m, nil
 		}
 		m.loading = false
+		m.morePages = 0
+		m.prefetching = false
 		if msg.err != nil {
-			m.errMsg = msg.err.Error()
+			m.fail("", msg.err, retryStations)
 			m.stations = nil
 			m.hasMore = false
+			m.listCached = false
+			m.listStale = false
 			m.selected = 0
 			return m, nil
 		}
 		m.errMsg = ""
+		m.retry = nil
 		m.stations = msg.stations
 		m.hasMore = msg.hasMore
+		m.listCached = msg.cached
+		m.listStale = msg.stale
 		m.selected = 0
 		m.ensureSelection()
 		m.updateDialRange()
 		m.snapDial()
+		return m, m.checkStreamsCmd()
+	case streamCheckedMsg:
+		return m, waitUpdatesCmd(msg.updates)
+	case addStationProbedMsg:
+		return m.updateAddStationProbed(msg)
+	case addStationSubmittedMsg:
+		return m.updateAddStationSubmitted(msg)
+	case playlistDoneMsg:
+		return m.updatePlaylistDone(msg)
+	case titleMsg:
+		return m.updateTitle(msg)
+	case historyClearedMsg:
+		return m.updateHistoryCleared(msg)
+	case presetsSavedMsg:
+		if msg.err != nil {
+			m.errMsg = "Failed to save presets: " + msg.err.Error()
+		}
+		return m, nil
+	case locationSavedMsg:
+		if msg.err != nil {
+			m.errMsg = "Failed to save location: " + msg.err.Error()
+		}
+		return m, nil
+	case favoritesRefreshedMsg:
+		// A failed refresh keeps the stored snapshots; offline use is normal.
+		if msg.err != nil || m.favorites == nil {
+			return m, nil
+		}
+		if m.isFavoritesSource() {
+			for i, station := range m.stations {
+				if fav, ok := m.favorites.Get(station.UUID); ok {
+					m.stations[i] = favoriteStation(fav)
+				}
+			}
+			m.updateDialRange()
+		}
+		if msg.result.Missing > 0 || msg.result.Broken > 0 {
+			m.errMsg = fmt.Sprintf("Favorites: %d no longer listed, %d broken", msg.result.Missing, msg.result.Broken)
+		}
 		return m, nil
 	case ipcReadyMsg:
 		if msg.err != nil {
@@ -388,13 +592,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ipcClosedMsg:
 		m.ipc = nil
 		return m, nil
+	case playerDownloadProgressMsg:
+		m.downloadDone = msg.done
+		m.downloadTotal = msg.total
+		if m.downloadingPlayer && strings.HasPrefix(m.errMsg, "Audio player not found. Downloading ffplay") {
+			m.errMsg = "Audio player not found. " + m.downloadStatus()
+		}
+		return m, waitUpdatesCmd(msg.updates)
 	case playerDownloadMsg:
 		m.downloadingPlayer = false
 		if msg.err != nil {
 			m.errMsg = "Failed to download ffplay: " + msg.err.Error() + " (install mpv or ffplay and ensure it is in PATH)"
 			return m, nil
 		}
-		p, err := player.New()
+		p, err := player.NewWithOptions(m.playerOptions())
 		if err != nil {
 			m.errMsg = "Audio player not available: " + err.Error()
 			return m, nil
@@ -404,10 +615,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.downloadingPlayer = false
 		m.errMsg = ""
 		return m, nil
+	case browseItemsMsg:
+		return m.updateBrowseItems(msg)
 	case countriesMsg:
 		m.countryLoading = false
 		if msg.err != nil {
-			m.errMsg = msg.err.Error()
+			m.fail("", msg.err, retryCountries)
 			m.countries = nil
 			m.filteredCountries = nil
 			m.countryIndex = 0
@@ -420,25 +633,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case playMsg:
 		if msg.err != nil {
-			m.errMsg = msg.err.Error()
+			m.fail("", msg.err, retryPlay(msg.station))
 			return m, nil
 		}
 		if m.player == nil {
 			if m.downloadingPlayer {
-				m.errMsg = "Audio player not available yet. Downloading ffplay..."
+				m.errMsg = "Audio player not available yet. " + m.downloadStatus()
 			} else {
 				m.errMsg = "Audio player not available. Install mpv or ffplay and ensure it is in PATH."
 			}
 			return m, nil
 		}
-		if err := m.player.Play(msg.url); err != nil {
+		var err error
+		if cp, ok := m.player.(player.CodecPlayer); ok {
+			err = cp.PlayCodec(msg.url, msg.station.Codec)
+		} else {
+			err = m.player.Play(msg.url)
+		}
+		if err != nil {
 			m.errMsg = err.Error()
 			return m, nil
 		}
 		m.errMsg = ""
+		m.retry = nil
 		m.playing = true
 		m.playingUUID = msg.station.UUID
+		m.playingBackend = ""
+		if named, ok := m.player.(player.NamedBackend); ok {
+			m.playingBackend = named.Name()
+		}
 		m.lastStation = msg.station
+		return m, m.recordPlay(msg.station)
+	case voteMsg:
+		if msg.err != nil {
+			delete(m.pendingVotes, msg.station.UUID)
+			m.fail("Vote failed: ", msg.err, retryVote(msg.station))
+			return m, nil
+		}
+		if m.votes != nil {
+			if err := m.votes.Record(msg.station.UUID, time.Now()); err != nil {
+				m.errMsg = "Voted, but failed to save vote history: " + err.Error()
+				return m, nil
+			}
+		}
+		m.errMsg = "Voted for " + msg.station.Name
 		return m, nil
 	case dialTickMsg:
 		return m.updateDialAnimation()
@@ -452,6 +690,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) playerOptions() player.Options {
+	return player.Options{
+		Priority: m.cfg.Backends,
+		Codecs:   m.cfg.CodecBackends,
+		SinkPath: m.cfg.SinkPath,
+	}
+}
+
 func (m Model) saveThemeCmd() tea.Cmd {
 	slug := m.theme.Slug
 	return func() tea.Msg {
@@ -461,34 +707,42 @@ func (m Model) saveThemeCmd() tea.Cmd {
 }
 
 func (m Model) loadStationsCmd() tea.Cmd {
+	// Starting a load abandons the previous one, e.g. when paging quickly.
+	loadCtx := m.stationLoads.next()
 	source := m.stationSource
 	country := m.country
 	search := strings.TrimSpace(m.activeSearch)
 	page := m.page
 	api := m.api
 	favorites := m.favorites
+	filter := m.activeFilter
+	browse := m.activeBrowse
+	chart := m.activeChart
+	chartCountry := m.chartCountry
+	nearby := m.nearby
+	radiusKm := m.cfg.NearbyRadiusKm
+	pageSize := m.pageSize()
+	key := m.activeQueryKey()
+	group := m.favGroup
+	history := m.history
+	order := m.historyOrder
 	return func() tea.Msg {
-		if source == sourceFavorites {
+		if source == sourceFavorites || source == sourceHistory {
 			all := []radio.Station{}
-			if favorites != nil {
+			switch {
+			case source == sourceHistory:
+				all = historyStations(history, order, time.Now())
+			case favorites != nil && group != "":
+				all = favoritesToStations(favorites.ListGroup(group))
+			case favorites != nil:
 				all = favoritesToStations(favorites.List())
 			}
 
 			if search != "" {
-				filtered := make([]radio.Station, 0, len(all))
-				searchLower := strings.ToLower(search)
-				for _, station := range all {
-					name := strings.ToLower(station.Name)
-					tags := strings.ToLower(station.Tags)
-					countryName := strings.ToLower(station.Country)
-					if strings.Contains(name, searchLower) || strings.Contains(tags, searchLower) || strings.Contains(countryName, searchLower) {
-						filtered = append(filtered, station)
-					}
-				}
-				all = filtered
+				all = filterStations(all, search)
 			}
 
-			offset := page * stationPageSize
+			offset := page * pageSize
 			if offset < 0 {
 				offset = 0
 			}
@@ -499,11 +753,12 @@ func (m Model) loadStationsCmd() tea.Cmd {
 					page:     page,
 					country:  country,
 					search:   search,
+					query:    key,
 					hasMore:  false,
 				}
 			}
 
-			end := offset + stationPageSize
+			end := offset + pageSize
 			hasMore := false
 			if end < len(all) {
 				hasMore = true
@@ -517,6 +772,7 @@ func (m Model) loadStationsCmd() tea.Cmd {
 				page:     page,
 				country:  country,
 				search:   search,
+				query:    key,
 				hasMore:  hasMore,
 			}
 		}
@@ -524,17 +780,44 @@ func (m Model) loadStationsCmd() tea.Cmd {
 		if api == nil {
 			return stationsMsg{err: fmt.Errorf("radio api not available"), source: source}
 		}
-		offset := page * stationPageSize
-		limit := stationPageSize + 1
+		offset := page * pageSize
+		limit := pageSize + 1
 
 		var (
 			stations []radio.Station
 			err      error
 		)
-		if search == "" {
-			stations, err = api.StationsByCountryPage(context.Background(), country, limit, offset)
-		} else {
-			stations, err = api.SearchStationsByCountry(context.Background(), country, search, limit, offset)
+		ctx, info := radio.WithResponseInfo(loadCtx)
+		switch {
+		case source == sourceFilter:
+			params := filter
+			if search != "" {
+				params.Name = search
+			}
+			params.Limit = limit
+			params.Offset = offset
+			stations, err = api.Search(ctx, params)
+		case source == sourceCharts && search != "":
+			params := radio.SearchParams{Name: search, CountryCode: chartCountry, Order: chart.SearchOrder(), Limit: limit, Offset: offset}
+			stations, err = api.Search(ctx, params)
+		case source == sourceCharts:
+			stations, err = api.ChartStations(ctx, chart, chartCountry, limit, offset)
+		case source == sourceNearby && nearby != nil:
+			stations, err = api.StationsNear(ctx, *nearby, radiusKm, nearbyLimit)
+			if err == nil && search != "" {
+				stations = filterStations(stations, search)
+			}
+			stations = nearbyPage(stations, limit, offset)
+		case source == sourceNearby:
+			err = fmt.Errorf("no position set, press A to enter one")
+		case source == sourceWorld:
+			stations, err = api.Search(ctx, radio.SearchParams{Name: search, Limit: limit, Offset: offset})
+		case source == sourceTag || source == sourceLanguage:
+			stations, err = browseStationsPage(ctx, api, source, browse, search, limit, offset)
+		case search == "":
+			stations, err = api.StationsByCountryPage(ctx, country, limit, offset)
+		default:
+			stations, err = api.SearchStationsByCountry(ctx, country, search, limit, offset)
 		}
 		if err != nil {
 			return stationsMsg{
@@ -543,12 +826,13 @@ func (m Model) loadStationsCmd() tea.Cmd {
 				page:    page,
 				country: country,
 				search:  search,
+				query:   key,
 			}
 		}
 
-		hasMore := len(stations) > stationPageSize
+		hasMore := len(stations) > pageSize
 		if hasMore {
-			stations = stations[:stationPageSize]
+			stations = stations[:pageSize]
 		}
 		return stationsMsg{
 			stations: stations,
@@ -556,24 +840,79 @@ func (m Model) loadStationsCmd() tea.Cmd {
 			page:     page,
 			country:  country,
 			search:   search,
+			query:    key,
 			hasMore:  hasMore,
+			cached:   info.Cached(),
+			stale:    info.Stale(),
 		}
 	}
 }
 
 func (m Model) playStationCmd(station radio.Station) tea.Cmd {
 	api := m.api
+	countClick := !m.cfg.DisableClickReporting
 	return func() tea.Msg {
+		if station.Custom() {
+			// Custom stations are unknown to the API; play their own URL.
+			streamURL, err := radio.StreamURL(station)
+			return playMsg{station: station, url: streamURL, err: err}
+		}
+		if !countClick {
+			if streamURL, err := radio.StreamURL(station); err == nil {
+				return playMsg{station: station, url: streamURL}
+			}
+		}
 		if api == nil {
 			return playMsg{err: fmt.Errorf("radio api not available")}
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
 		defer cancel()
-		streamURL, err := api.ResolveStationURL(ctx, station.UUID)
+		var (
+			streamURL string
+			err       error
+		)
+		if countClick {
+			streamURL, err = api.CountClick(ctx, station.UUID)
+		} else {
+			streamURL, err = api.StationURL(ctx, station.UUID)
+		}
 		return playMsg{station: station, url: streamURL, err: err}
 	}
 }
 
+// voteStation votes for station unless it was voted for recently.
+func (m Model) voteStation(station radio.Station) (tea.Model, tea.Cmd) {
+	if station.Custom() {
+		m.errMsg = station.Name + " is a custom station and cannot be voted for"
+		return m, nil
+	}
+	if m.votes != nil {
+		if next := m.votes.NextVote(station.UUID, time.Now()); !next.IsZero() {
+			m.errMsg = fmt.Sprintf("Already voted for %s; you can vote again after %s", station.Name, next.Format("Jan 2 15:04"))
+			return m, nil
+		}
+	}
+	if m.pendingVotes[station.UUID] {
+		m.errMsg = "Already voting for " + station.Name
+		return m, nil
+	}
+	if m.pendingVotes == nil {
+		m.pendingVotes = map[string]bool{}
+	}
+	// Held until the vote is answered so a quick second press is refused;
+	// after a successful vote it also guards sessions without vote history.
+	m.pendingVotes[station.UUID] = true
+	api := m.api
+	return m, func() tea.Msg {
+		if api == nil {
+			return voteMsg{station: station, err: fmt.Errorf("radio api not available")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 12*time.Second)
+		defer cancel()
+		return voteMsg{station: station, err: api.Vote(ctx, station.UUID)}
+	}
+}
+
 func (m Model) loadCountriesCmd() tea.Cmd {
 	api := m.api
 	return func() tea.Msg {
@@ -593,11 +932,44 @@ func (m Model) maybeDownloadPlayerCmd() tea.Cmd {
 		return nil
 	}
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
-		defer cancel()
-		path, err := player.DownloadFFplay(ctx)
-		return playerDownloadMsg{path: path, err: err}
+		updates := make(chan tea.Msg, 1)
+		go func() {
+			defer close(updates)
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+			defer cancel()
+			path, err := player.DownloadFFplay(ctx, func(done, total int64) {
+				// Drop intermediate updates while the UI is busy; only the latest matters.
+				select {
+				case updates <- playerDownloadProgressMsg{done: done, total: total, updates: updates}:
+				default:
+				}
+			})
+			updates <- playerDownloadMsg{path: path, err: err}
+		}()
+		return waitUpdatesCmd(updates)()
+	}
+}
+
+// waitUpdatesCmd waits for the next message from a background job, such as
+// a download or the stream checks; it yields nil once the job closes
+// updates.
+func waitUpdatesCmd(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// downloadStatus describes the ffplay download, including percent complete when known.
+func (m Model) downloadStatus() string {
+	if m.downloadTotal > 0 {
+		percent := int(m.downloadDone * 100 / m.downloadTotal)
+		return fmt.Sprintf("Downloading ffplay in the background... %d%%", min(percent, 100))
 	}
+	return "Downloading ffplay in the background..."
 }
 
 func (m Model) startIPCCmd() tea.Cmd {
@@ -636,7 +1008,12 @@ func (m Model) updateDialAnimation() (tea.Model, tea.Cmd) {
 		m.dialPos = m.dialTarget
 		return m, nil
 	}
-	step := math.Copysign(0.4, diff)
+	stepSize := 0.4
+	if m.dialUseDistance {
+		// Kilometre ranges are far wider than the FM band.
+		stepSize = math.Max(stepSize, (m.dialMax-m.dialMin)/50)
+	}
+	step := math.Copysign(stepSize, diff)
 	if math.Abs(step) > math.Abs(diff) {
 		step = diff
 	}
@@ -762,9 +1139,7 @@ func (m Model) handleIPC(msg ipcMsg) (tea.Model, tea.Cmd) {
 	case "QUIT":
 		reply = ipcReply{ok: true}
 		sendIPCReply(msg.reply, reply)
-		if m.player != nil {
-			_ = m.player.Stop()
-		}
+		m.stopPlayback()
 		if m.ipc != nil {
 			m.ipc.Close()
 		}
@@ -774,6 +1149,10 @@ func (m Model) handleIPC(msg ipcMsg) (tea.Model, tea.Cmd) {
 	case "PING":
 		reply = ipcReply{ok: true, data: "OK"}
 	default:
+		if arg, ok := strings.CutPrefix(cmd, "PRESET "); ok {
+			cmdTea, reply = m.ipcPreset(arg)
+			break
+		}
 		reply = ipcReply{ok: false, err: "unknown command"}
 	}
 
@@ -783,10 +1162,7 @@ func (m Model) handleIPC(msg ipcMsg) (tea.Model, tea.Cmd) {
 
 func (m *Model) ipcPlayPause() (tea.Cmd, ipcReply) {
 	if m.playing {
-		if m.player != nil {
-			_ = m.player.Stop()
-		}
-		m.playing = false
+		m.stopPlayback()
 		return nil, ipcReply{ok: true}
 	}
 
@@ -949,26 +1325,161 @@ func (m *Model) visibleCountries() []radio.Country {
 	return m.countries
 }
 
+// loadScope cancels a superseded station load when a newer one starts. It
+// is shared by every copy of the Model.
+type loadScope struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (s *loadScope) next() context.Context {
+	if s == nil {
+		return context.Background()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	return ctx
+}
+
+// stop cancels the current load without starting another.
+func (s *loadScope) stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+// filterStations keeps stations whose name, tags or country contain search.
+func filterStations(stations []radio.Station, search string) []radio.Station {
+	filtered := make([]radio.Station, 0, len(stations))
+	searchLower := strings.ToLower(search)
+	for _, station := range stations {
+		name := strings.ToLower(station.Name)
+		tags := strings.ToLower(station.Tags)
+		countryName := strings.ToLower(station.Country)
+		if strings.Contains(name, searchLower) || strings.Contains(tags, searchLower) || strings.Contains(countryName, searchLower) {
+			filtered = append(filtered, station)
+		}
+	}
+	return filtered
+}
+
 func (m *Model) isFavoritesSource() bool {
 	return m.stationSource == sourceFavorites
 }
 
+// toggleWorldwide switches between the current country and a search across
+// all countries, keeping any active search text.
+func (m Model) toggleWorldwide() (tea.Model, tea.Cmd) {
+	switch m.stationSource {
+	case sourceWorld:
+		m.stationSource = sourceCountry
+	case sourceFavorites, sourceHistory:
+		m.stationSource = sourceWorld
+		m.activeSearch = ""
+		m.search.SetValue("")
+	default:
+		m.stationSource = sourceWorld
+	}
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	m.errMsg = ""
+	return m, m.loadStationsCmd()
+}
+
+// activeQueryKey identifies the filter, tag or language behind the current
+// list, so results for a previous query can be discarded.
+func (m Model) activeQueryKey() string {
+	switch m.stationSource {
+	case sourceFavorites:
+		if m.favGroup != "" {
+			return "group:" + m.favGroup
+		}
+	case sourceFilter:
+		return filterKey(m.activeFilter)
+	case sourceTag:
+		return "tag:" + m.activeBrowse
+	case sourceLanguage:
+		return "language:" + m.activeBrowse
+	case sourceCharts:
+		return "chart:" + string(m.activeChart) + ":" + m.chartCountry
+	case sourceNearby:
+		if m.nearby != nil {
+			return "nearby:" + m.nearby.String()
+		}
+	case sourceHistory:
+		if m.historyOrder == historyMostListened {
+			return "history:most"
+		}
+	}
+	return ""
+}
+
 func favoritesToStations(favs []config.Favorite) []radio.Station {
 	stations := make([]radio.Station, 0, len(favs))
 	for _, fav := range favs {
 		if strings.TrimSpace(fav.UUID) == "" {
 			continue
 		}
-		stations = append(stations, radio.Station{
-			UUID:    fav.UUID,
-			Name:    fav.Name,
-			Country: fav.Country,
-			Tags:    fav.Tags,
-		})
+		stations = append(stations, favoriteStation(fav))
 	}
 	return stations
 }
 
+// favoriteStation prefers the full station snapshot over the basic fields
+// older favorites files carry.
+func favoriteStation(fav config.Favorite) radio.Station {
+	if fav.Station != nil {
+		station := *fav.Station
+		station.UUID = fav.UUID
+		return station
+	}
+	return radio.Station{
+		UUID:    fav.UUID,
+		Name:    fav.Name,
+		Country: fav.Country,
+		Tags:    fav.Tags,
+	}
+}
+
+// refreshFavoritesCmd looks up every favorite in the background so stored
+// snapshots pick up new stream URLs and missing or broken stations are flagged.
+func (m Model) refreshFavoritesCmd() tea.Cmd {
+	api := m.api
+	favorites := m.favorites
+	if api == nil || favorites == nil || favorites.Count() == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		favs := favorites.List()
+		uuids := make([]string, 0, len(favs))
+		for _, fav := range favs {
+			uuids = append(uuids, fav.UUID)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		stations, err := api.StationsByUUIDs(ctx, uuids)
+		if err != nil {
+			return favoritesRefreshedMsg{err: err}
+		}
+		result, err := favorites.Refresh(uuids, stations, time.Now())
+		return favoritesRefreshedMsg{result: result, err: err}
+	}
+}
+
 func (m *Model) snapDial() {
 	m.dialTarget = m.dialValueForIndex(m.selected)
 	m.dialPos = m.dialTarget
@@ -978,6 +1489,7 @@ func (m *Model) updateDialRange() {
 	list := m.visibleStations()
 	if len(list) == 0 {
 		m.dialUseFreq = false
+		m.dialUseDistance = false
 		m.dialMin = 0
 		m.dialMax = 0
 		return
@@ -999,6 +1511,7 @@ func (m *Model) updateDialRange() {
 		}
 	}
 
+	m.dialUseDistance = false
 	if count >= 2 && max > min {
 		m.dialUseFreq = true
 		m.dialMin = min
@@ -1009,6 +1522,28 @@ func (m *Model) updateDialRange() {
 	m.dialUseFreq = false
 	m.dialMin = 0
 	m.dialMax = 0
+	if m.stationSource == sourceNearby {
+		m.updateDistanceDialRange(list)
+	}
+}
+
+// updateDistanceDialRange spans the dial from the nearest to the farthest
+// station when at least two stations have a position.
+func (m *Model) updateDistanceDialRange(list []radio.Station) {
+	nearest, farthest := math.MaxFloat64, 0.0
+	count := 0
+	for _, station := range list {
+		if distance, ok := m.stationDistance(station); ok {
+			count++
+			nearest = math.Min(nearest, distance)
+			farthest = math.Max(farthest, distance)
+		}
+	}
+	if count >= 2 && farthest > nearest {
+		m.dialUseDistance = true
+		m.dialMin = nearest
+		m.dialMax = farthest
+	}
 }
 
 func (m Model) dialValueForIndex(index int) float64 {
@@ -1026,6 +1561,13 @@ func (m Model) dialValueForIndex(index int) float64 {
 			return m.dialMin + (m.dialMax-m.dialMin)*frac
 		}
 	}
+	if m.dialUseDistance {
+		if distance, ok := m.stationDistance(list[index]); ok {
+			return distance
+		}
+		// Stations without a position sit at the far end of the dial.
+		return m.dialMax
+	}
 
 	return float64(index)
 }
@@ -1,9 +1,25 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/generators"
+	"github.com/gopxl/beep/v2/wav"
 
 	"radio-tui/internal/config"
+	"radio-tui/internal/player"
 	"radio-tui/internal/radio"
 )
 
@@ -493,3 +509,375 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestModel_PlayMsg_NullBackend(t *testing.T) {
+	format := beep.Format{SampleRate: 22050, NumChannels: 2, Precision: 2}
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := wav.Encode(f, generators.Silence(1024), format); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	f.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		http.ServeFile(w, r, path)
+	}))
+	defer server.Close()
+
+	backend, err := player.NewWithOptions(player.Options{Priority: []string{"null"}})
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	m := createTestModel()
+	m.player = backend
+
+	updated, _ := m.Update(playMsg{station: m.stations[1], url: server.URL + "/stream.wav"})
+	got := updated.(Model)
+	if got.errMsg != "" {
+		t.Fatalf("errMsg = %q, want empty", got.errMsg)
+	}
+	if !got.playing || got.playingUUID != "2" {
+		t.Errorf("playing/playingUUID = %v/%q, want true/2", got.playing, got.playingUUID)
+	}
+	if got.playingBackend != "null" {
+		t.Errorf("playingBackend = %q, want null", got.playingBackend)
+	}
+	_ = backend.Stop()
+}
+
+func TestModel_PlayerDownloadProgress(t *testing.T) {
+	m := createTestModel()
+	m.missingPlayer = true
+	m.downloadingPlayer = true
+	m.errMsg = "Audio player not found. Downloading ffplay in the background..."
+
+	updates := make(chan tea.Msg, 1)
+	updated, cmd := m.Update(playerDownloadProgressMsg{done: 250, total: 1000, updates: updates})
+	got := updated.(Model)
+	if got.errMsg != "Audio player not found. Downloading ffplay in the background... 25%" {
+		t.Errorf("errMsg = %q", got.errMsg)
+	}
+	if cmd == nil {
+		t.Fatal("progress update should wait for the next download message")
+	}
+
+	updates <- playerDownloadMsg{path: "ffplay.exe"}
+	if _, ok := cmd().(playerDownloadMsg); !ok {
+		t.Error("wait command should deliver the next download message")
+	}
+	close(updates)
+	if msg := waitUpdatesCmd(updates)(); msg != nil {
+		t.Errorf("closed channel should yield nil, got %T", msg)
+	}
+}
+
+func TestModel_DownloadStatus_UnknownTotal(t *testing.T) {
+	m := createTestModel()
+	m.downloadDone = 500
+	m.downloadTotal = -1
+
+	if got := m.downloadStatus(); got != "Downloading ffplay in the background..." {
+		t.Errorf("downloadStatus() = %q", got)
+	}
+}
+
+// useTempConfigDir points os.UserConfigDir at a temp directory.
+func useTempConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestModel_VoteKey(t *testing.T) {
+	useTempConfigDir(t)
+	var votes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/vote/1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		votes.Add(1)
+		w.Write([]byte(`{"ok":true,"message":"voted for station successfully"}`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := createTestModel()
+	m.api = api
+	if m.votes, err = config.LoadVotes(); err != nil {
+		t.Fatalf("LoadVotes() error = %v", err)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if cmd == nil {
+		t.Fatal("vote key should return a command")
+	}
+	updated, _ = updated.(Model).Update(cmd())
+	got := updated.(Model)
+	if got.errMsg != "Voted for Rock FM" {
+		t.Errorf("errMsg = %q", got.errMsg)
+	}
+
+	updated, cmd = got.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if cmd != nil {
+		t.Error("second vote should be refused locally")
+	}
+	if !strings.HasPrefix(updated.(Model).errMsg, "Already voted for Rock FM") {
+		t.Errorf("errMsg = %q", updated.(Model).errMsg)
+	}
+	if votes.Load() != 1 {
+		t.Errorf("server votes = %d, want 1", votes.Load())
+	}
+
+	// The rate limit survives a restart.
+	reloaded, err := config.LoadVotes()
+	if err != nil {
+		t.Fatalf("LoadVotes() error = %v", err)
+	}
+	if !reloaded.NextVote("1", time.Now()).After(time.Now()) {
+		t.Error("vote should be persisted")
+	}
+}
+
+func TestModel_VoteKeyDoublePress(t *testing.T) {
+	useTempConfigDir(t)
+	var votes atomic.Int32
+	var accept atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		votes.Add(1)
+		if !accept.Load() {
+			w.Write([]byte(`{"ok":false,"message":"server busy"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"message":"voted for station successfully"}`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := createTestModel()
+	m.api = api
+	if m.votes, err = config.LoadVotes(); err != nil {
+		t.Fatalf("LoadVotes() error = %v", err)
+	}
+
+	updated, first := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	updated, second := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if first == nil || second != nil {
+		t.Fatalf("commands = %v, %v; the second press should be refused while the vote is pending", first, second)
+	}
+	if got := updated.(Model).errMsg; got != "Already voting for Rock FM" {
+		t.Errorf("errMsg = %q", got)
+	}
+
+	// A failed vote can be tried again.
+	updated, _ = updated.(Model).Update(first())
+	updated, retry := updated.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if retry == nil {
+		t.Fatal("vote should be possible again after a failure")
+	}
+	accept.Store(true)
+	updated.(Model).Update(retry())
+	if votes.Load() != 2 {
+		t.Errorf("server votes = %d, want 2", votes.Load())
+	}
+}
+
+func TestModel_PlayStation_ClickReporting(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"stationuuid":"1","url_resolved":"http://stream.example.com"}`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := createTestModel()
+	m.api = api
+
+	msg := m.playStationCmd(m.stations[0])().(playMsg)
+	if msg.err != nil || msg.url != "http://stream.example.com" {
+		t.Fatalf("playMsg = %+v", msg)
+	}
+	if len(paths) != 1 || paths[0] != "/json/url/1" {
+		t.Errorf("requests = %v, want a click on /json/url/1", paths)
+	}
+
+	m.cfg.DisableClickReporting = true
+	station := m.stations[0]
+	station.URLResolved = "http://known.example.com"
+	msg = m.playStationCmd(station)().(playMsg)
+	if msg.url != "http://known.example.com" || len(paths) != 1 {
+		t.Errorf("url = %q, requests = %v; want known URL without API calls", msg.url, paths)
+	}
+}
+
+func TestModel_WorldwideSearch(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		mu.Unlock()
+		w.Write([]byte(`[{"stationuuid":"a","name":"BBC Radio 1","countrycode":"gb"},{"stationuuid":"b","name":"BBC Arabic","countrycode":"EG"}]`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+	m.activeSearch = "bbc"
+	m.page = 2
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	m = updated.(Model)
+	if m.stationSource != sourceWorld || m.page != 0 || m.activeSearch != "bbc" || cmd == nil {
+		t.Fatalf("source/page/search = %v/%d/%q", m.stationSource, m.page, m.activeSearch)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+
+	if len(queries) != 1 || !strings.HasPrefix(queries[0], "/json/stations/search?") {
+		t.Fatalf("requests = %v", queries)
+	}
+	if strings.Contains(queries[0], "countrycode") || !strings.Contains(queries[0], "name=bbc") {
+		t.Errorf("worldwide search should not filter by country: %s", queries[0])
+	}
+	list := m.renderList(80, 5)
+	for _, want := range []string{"Worldwide Search", "GB", "EG"} {
+		if !strings.Contains(list, want) {
+			t.Errorf("list should contain %q, got %q", want, list)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if updated.(Model).stationSource != sourceCountry {
+		t.Error("W should toggle back to the country list")
+	}
+}
+
+func TestModel_RefreshFavorites(t *testing.T) {
+	useTempConfigDir(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/stations/byuuid" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"stationuuid":"1","name":"Rock FM","bitrate":192,"codec":"MP3","url_resolved":"http://rock.example.com"}]`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	favorites, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	for _, station := range []radio.Station{{UUID: "2", Name: "Gone FM"}, {UUID: "1", Name: "Rock FM"}} {
+		if _, err := favorites.Toggle(station); err != nil {
+			t.Fatalf("Toggle() error = %v", err)
+		}
+	}
+
+	m := *createTestModel()
+	m.api = api
+	m.favorites = favorites
+	m.stationSource = sourceFavorites
+	m.stations = favoritesToStations(favorites.List())
+
+	updated, _ := m.Update(m.refreshFavoritesCmd()())
+	m = updated.(Model)
+	if m.errMsg != "Favorites: 1 no longer listed, 0 broken" {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+	if m.stations[1].UUID != "1" || m.stations[1].Bitrate != 192 {
+		t.Errorf("refreshed favorite = %+v", m.stations[1])
+	}
+	m.selected = 0
+	if meta := m.renderStationMeta(); !strings.Contains(meta, missingFavoriteWarning) {
+		t.Errorf("meta should flag the missing favorite, got %q", meta)
+	}
+
+	// The fuller snapshot survives a restart.
+	reloaded, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	if fav, _ := reloaded.Get("1"); favoriteStation(fav).URLResolved != "http://rock.example.com" {
+		t.Errorf("stored favorite = %+v", fav)
+	}
+}
+
+func TestModel_LoadStations_CancelsSupersededLoad(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "0" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		w.Write([]byte(`[{"stationuuid":"x","name":"Page Two FM"}]`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+	m.stationLoads = &loadScope{}
+
+	first := m.loadStationsCmd()
+	firstMsg := make(chan tea.Msg, 1)
+	go func() { firstMsg <- first() }()
+
+	m.page = 1
+	m.loading = true
+	second := m.loadStationsCmd()
+
+	select {
+	case msg := <-firstMsg:
+		if err := msg.(stationsMsg).err; !errors.Is(err, context.Canceled) {
+			t.Errorf("superseded load error = %v, want context.Canceled", err)
+		}
+		updated, _ := m.Update(msg)
+		if !updated.(Model).loading || updated.(Model).errMsg != "" {
+			t.Error("a cancelled load should be ignored")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("superseded load was not cancelled")
+	}
+
+	updated, _ := m.Update(second())
+	m = updated.(Model)
+	if m.loading || len(m.stations) != 1 || m.stations[0].Name != "Page Two FM" {
+		t.Errorf("stations = %+v, loading = %v", m.stations, m.loading)
+	}
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+// nearbyLimit is how many stations in range are fetched; the nearby list is
+// sorted by distance locally, so it is paged from this one result.
+const nearbyLimit = 500
+
+type locationSavedMsg struct{ err error }
+
+func newNearbyInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "Near (lat, long): "
+	input.Placeholder = "52.52, 13.405"
+	input.CharLimit = 40
+	input.Width = 26
+	return input
+}
+
+func (m Model) openNearbyInput() (tea.Model, tea.Cmd) {
+	if m.nearbyInput.Prompt == "" {
+		m.nearbyInput = newNearbyInput()
+	}
+	value := ""
+	switch {
+	case m.nearby != nil:
+		value = m.nearby.String()
+	case m.cfg.Location != nil:
+		value = m.cfg.Location.String()
+	}
+	m.nearbyInput.SetValue(value)
+	m.nearbyInput.Focus()
+	m.nearbyInput.CursorEnd()
+	m.inputMode = inputNearby
+	return m, textinput.Blink
+}
+
+func (m Model) updateNearbyInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.nearbyInput, cmd = m.nearbyInput.Update(msg)
+
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter":
+			point, err := radio.ParsePoint(m.nearbyInput.Value())
+			if err != nil {
+				m.errMsg = err.Error()
+				return m, cmd
+			}
+			m.inputMode = inputNone
+			m.nearbyInput.Blur()
+			m.nearby = &point
+			m.stationSource = sourceNearby
+			m.activeSearch = ""
+			m.search.SetValue("")
+			m.page = 0
+			m.hasMore = false
+			m.selected = 0
+			m.loading = true
+			m.errMsg = ""
+			cmds := []tea.Cmd{m.loadStationsCmd()}
+			if m.cfg.Location == nil || *m.cfg.Location != point {
+				m.cfg.Location = &point
+				cmds = append(cmds, saveLocationCmd(point))
+			}
+			return m, tea.Batch(cmds...)
+		case "esc":
+			m.inputMode = inputNone
+			m.nearbyInput.Blur()
+			return m, nil
+		}
+	}
+
+	return m, cmd
+}
+
+func saveLocationCmd(point radio.Point) tea.Cmd {
+	return func() tea.Msg {
+		return locationSavedMsg{err: config.SaveLocation(point)}
+	}
+}
+
+// nearbyPage slices one page out of the distance-sorted nearby stations,
+// keeping the extra element loadStationsCmd uses to detect more pages.
+func nearbyPage(stations []radio.Station, limit int, offset int) []radio.Station {
+	if offset >= len(stations) {
+		return nil
+	}
+	return stations[offset:min(offset+limit, len(stations))]
+}
+
+// stationDistance is the distance from the nearby origin, if known.
+func (m Model) stationDistance(station radio.Station) (float64, bool) {
+	if m.nearby == nil || !station.HasGeo() {
+		return 0, false
+	}
+	return radio.DistanceKm(*m.nearby, station.Point()), true
+}
+
+func formatDistance(km float64) string {
+	if km < 100 {
+		return fmt.Sprintf("%.1fkm", km)
+	}
+	return fmt.Sprintf("%.0fkm", km)
+}
+
+func (m Model) nearbyTitle() string {
+	if m.nearby == nil {
+		return "Nearby"
+	}
+	radius := m.cfg.NearbyRadiusKm
+	if radius <= 0 {
+		radius = radio.DefaultNearbyRadiusKm
+	}
+	return fmt.Sprintf("Nearby %s (%s)", strings.TrimSpace(m.nearby.String()), formatDistance(radius))
+}
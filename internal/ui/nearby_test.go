@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func TestNearby_ListsStationsByDistance(t *testing.T) {
+	useTempConfigDir(t)
+	var (
+		mu      sync.Mutex
+		queries []url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query())
+		mu.Unlock()
+		w.Write([]byte(`[
+			{"stationuuid":"far","name":"Hamburg FM","geo_lat":53.55,"geo_long":9.99},
+			{"stationuuid":"near","name":"Berlin FM","geo_lat":52.50,"geo_long":13.40},
+			{"stationuuid":"mid","name":"Potsdam FM","geo_lat":52.39,"geo_long":13.06}
+		]`))
+	}))
+	defer server.Close()
+
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	m := *createTestModel()
+	m.api = api
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	if m.inputMode != inputNearby {
+		t.Fatalf("inputMode = %v, want nearby input", m.inputMode)
+	}
+	m = typeText(t, m, "52.52, 13.405")
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if m.stationSource != sourceNearby || m.nearby == nil || cmd == nil {
+		t.Fatalf("source = %v, nearby = %v", m.stationSource, m.nearby)
+	}
+
+	var loaded stationsMsg
+	for _, msg := range runBatch(cmd) {
+		if stations, ok := msg.(stationsMsg); ok {
+			loaded = stations
+		}
+	}
+	updated, _ = m.Update(loaded)
+	m = updated.(Model)
+
+	if len(queries) != 1 || queries[0].Get("geo_distance") != "100000" {
+		t.Fatalf("queries = %v", queries)
+	}
+	names := []string{}
+	for _, station := range m.stations {
+		names = append(names, station.Name)
+	}
+	if strings.Join(names, ",") != "Berlin FM,Potsdam FM,Hamburg FM" {
+		t.Errorf("stations = %v, want nearest first", names)
+	}
+	if list := m.renderList(80, 5); !strings.Contains(list, "2.2km") || !strings.Contains(list, "Nearby 52.5200, 13.4050") {
+		t.Errorf("list should show distances, got %q", list)
+	}
+	if !m.dialUseDistance || m.dialMax < 200 {
+		t.Errorf("dial should use distance without frequencies: use = %v, max = %v", m.dialUseDistance, m.dialMax)
+	}
+	if dial := m.renderDial(60, false, false); !strings.Contains(dial, "DISTANCE") || !strings.Contains(dial, "2.2 km") {
+		t.Errorf("dial = %q", dial)
+	}
+
+	if cfg := config.LoadConfig(); cfg.Location == nil || cfg.Location.Lat != 52.52 {
+		t.Errorf("location should be saved, got %+v", cfg.Location)
+	}
+}
+
+func TestNearby_InvalidPosition(t *testing.T) {
+	m := *createTestModel()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	m = typeText(t, m, "somewhere")
+	m, _ = pressKey(t, m, tea.KeyEnter)
+	if m.inputMode != inputNearby || m.errMsg == "" || m.stationSource == sourceNearby {
+		t.Errorf("mode = %v, errMsg = %q; an invalid position should keep the prompt open", m.inputMode, m.errMsg)
+	}
+}
+
+// runBatch runs a command and the commands of any batch it returns.
+func runBatch(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, runBatch(c)...)
+	}
+	return msgs
+}
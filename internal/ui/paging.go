@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+// pageSize is the configured number of stations per page. One more station
+// than a page is requested to learn whether another page follows, so the
+// size stays below radio.MaxPageSize.
+func (m Model) pageSize() int {
+	if m.cfg.PageSize <= 0 {
+		return defaultPageSize
+	}
+	return min(m.cfg.PageSize, radio.MaxPageSize-1)
+}
+
+// nextPage is the first page not yet in the list.
+func (m Model) nextPage() int {
+	return m.page + m.morePages + 1
+}
+
+// pageLabel names the pages in the list, e.g. "Page 2" or "Pages 2-4".
+func (m Model) pageLabel() string {
+	if m.morePages > 0 {
+		return fmt.Sprintf("Pages %d-%d", m.page+1, m.page+m.morePages+1)
+	}
+	return fmt.Sprintf("Page %d", m.page+1)
+}
+
+// selectionMoved follows a cursor move: the dial animates towards the new
+// station and the next page is prefetched when the end of the list nears.
+func (m Model) selectionMoved() (tea.Model, tea.Cmd) {
+	prefetch := m.maybePrefetch()
+	return m, tea.Batch(m.dialTickCmd(), prefetch)
+}
+
+// maybePrefetch starts loading the next page once the selection is within
+// prefetchDistance of the end of the list.
+func (m *Model) maybePrefetch() tea.Cmd {
+	if m.loading || m.prefetching || !m.hasMore {
+		return nil
+	}
+	if len(m.visibleStations())-m.selected > prefetchDistance {
+		return nil
+	}
+	return m.prefetchCmd()
+}
+
+// prefetchCmd loads the next page to be appended to the list. Like any
+// station load it is cancelled when a newer load starts.
+func (m *Model) prefetchCmd() tea.Cmd {
+	m.prefetching = true
+	next := *m
+	next.page = m.nextPage()
+	load := next.loadStationsCmd()
+	return func() tea.Msg {
+		msg, _ := load().(stationsMsg)
+		msg.more = true
+		return msg
+	}
+}
+
+func (m Model) updateMoreStations(msg stationsMsg) (tea.Model, tea.Cmd) {
+	if !m.prefetching || msg.source != m.stationSource || msg.page != m.nextPage() || msg.country != m.country || msg.search != m.activeSearch || msg.query != m.activeQueryKey() {
+		return m, nil
+	}
+	if errors.Is(msg.err, context.Canceled) {
+		return m, nil
+	}
+	m.prefetching = false
+	if msg.err != nil {
+		m.fail("Loading more stations failed: ", msg.err, retryMoreStations)
+		return m, nil
+	}
+
+	// The list may have shifted between requests; skip stations already shown.
+	seen := make(map[string]bool, len(m.stations))
+	for _, station := range m.stations {
+		seen[station.UUID] = true
+	}
+	stations := append([]radio.Station(nil), m.stations...)
+	for _, station := range msg.stations {
+		if !seen[station.UUID] {
+			stations = append(stations, station)
+		}
+	}
+	m.stations = stations
+	m.morePages++
+	m.hasMore = msg.hasMore
+	m.updateDialRange()
+	m.snapDial()
+	return m, m.checkStreamsCmd()
+}
+
+func retryMoreStations(m Model) (tea.Model, tea.Cmd) {
+	if m.prefetching || !m.hasMore {
+		return m, nil
+	}
+	cmd := m.prefetchCmd()
+	return m, cmd
+}
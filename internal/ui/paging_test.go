@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func TestModel_PageSize(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, defaultPageSize},
+		{50, 50},
+		{radio.MaxPageSize, radio.MaxPageSize - 1},
+	}
+	for _, tt := range tests {
+		m := Model{cfg: config.AppConfig{PageSize: tt.configured}}
+		if got := m.pageSize(); got != tt.want {
+			t.Errorf("pageSize() with page_size %d = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+// newPagedStationServer serves total numbered stations for any country,
+// honouring limit and offset.
+func newPagedStationServer(t *testing.T, total int) *radio.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		stations := []radio.Station{}
+		for i := offset; i < min(offset+limit, total); i++ {
+			stations = append(stations, radio.Station{UUID: fmt.Sprintf("s%d", i), Name: fmt.Sprintf("Station %d", i)})
+		}
+		json.NewEncoder(w).Encode(stations)
+	}))
+	t.Cleanup(server.Close)
+	api, err := radio.NewClient("TestApp/1.0", radio.WithServers(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return api
+}
+
+func findStationsMsg(msgs []tea.Msg) (stationsMsg, bool) {
+	for _, msg := range msgs {
+		if msg, ok := msg.(stationsMsg); ok {
+			return msg, true
+		}
+	}
+	return stationsMsg{}, false
+}
+
+func TestModel_PrefetchesNextPage(t *testing.T) {
+	m := *createTestModel()
+	m.api = newPagedStationServer(t, 12)
+	m.cfg.PageSize = 5
+	m.stationLoads = &loadScope{}
+	m.loading = true
+
+	updated, _ := m.Update(m.loadStationsCmd()())
+	m = updated.(Model)
+	if len(m.stations) != 5 || !m.hasMore {
+		t.Fatalf("first page = %d stations, hasMore = %v", len(m.stations), m.hasMore)
+	}
+
+	// The whole page is within prefetchDistance, so the first move prefetches.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(Model)
+	if !m.prefetching {
+		t.Fatal("moving near the end of the list should prefetch the next page")
+	}
+	msg, ok := findStationsMsg(runBatch(cmd))
+	if !ok || !msg.more || msg.page != 1 {
+		t.Fatalf("prefetch message = %+v", msg)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if len(m.stations) != 10 || m.selected != 1 || m.prefetching || !m.hasMore {
+		t.Fatalf("after prefetch: %d stations, selected %d, prefetching %v, hasMore %v", len(m.stations), m.selected, m.prefetching, m.hasMore)
+	}
+	if header := m.renderList(60, 3); !strings.Contains(header, "Stations (Pages 1-2)") {
+		t.Errorf("header should cover both pages:\n%s", header)
+	}
+
+	// Paging forward continues after the prefetched pages.
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{']'}})
+	m = updated.(Model)
+	updated, _ = m.Update(cmd())
+	m = updated.(Model)
+	if m.page != 2 || m.morePages != 0 || len(m.stations) != 2 || m.stations[0].UUID != "s10" || m.hasMore {
+		t.Errorf("page %d (+%d): %d stations starting %q, hasMore %v", m.page, m.morePages, len(m.stations), m.stations[0].UUID, m.hasMore)
+	}
+}
+
+func TestModel_PrefetchIgnoredAfterNewLoad(t *testing.T) {
+	m := *createTestModel()
+	m.hasMore = true
+	cmd := m.maybePrefetch()
+	if cmd == nil || !m.prefetching {
+		t.Fatal("maybePrefetch() should start a prefetch near the end of a list with more pages")
+	}
+
+	// A new list arrives before the prefetched page does.
+	m.stationSource = sourceWorld
+	m.page = 0
+	updated, _ := m.Update(stationsMsg{stations: m.stations, source: sourceWorld, country: m.country, query: m.activeQueryKey()})
+	m = updated.(Model)
+	updated, _ = m.Update(stationsMsg{stations: []radio.Station{{UUID: "late"}}, source: sourceCountry, country: m.country, page: 1, more: true})
+	m = updated.(Model)
+	if len(m.stations) != 5 || m.prefetching {
+		t.Errorf("stale prefetch should be dropped, got %d stations, prefetching %v", len(m.stations), m.prefetching)
+	}
+}
@@ -0,0 +1,347 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/playlist"
+)
+
+type playlistField int
+
+const (
+	playlistAction playlistField = iota
+	playlistFormat
+	playlistFile
+	playlistFieldCount
+)
+
+// playlistTimeout bounds an import, which looks up every entry on Radio
+// Browser one at a time.
+const playlistTimeout = 2 * time.Minute
+
+var playlistLabels = [playlistFieldCount]string{
+	playlistAction: "Action",
+	playlistFormat: "Format",
+	playlistFile:   "File",
+}
+
+// playlistForm is the overlay that exports favorites to a playlist file or
+// imports one. Format 0 is "auto": the file extension on export, the file
+// content on import.
+type playlistForm struct {
+	file      textinput.Model
+	focus     playlistField
+	importing bool
+	format    int
+	err       string
+	busy      bool
+	// seq identifies the current run so results arriving after the form
+	// was closed are ignored.
+	seq int
+}
+
+type playlistDoneMsg struct {
+	seq      int
+	path     string
+	export   bool
+	exported playlist.ExportResult
+	imported playlist.ImportResult
+	err      error
+}
+
+func newPlaylistForm() playlistForm {
+	file := textinput.New()
+	file.Prompt = ""
+	file.Width = 40
+	file.SetValue(defaultPlaylistPath())
+	return playlistForm{file: file}
+}
+
+// defaultPlaylistPath suggests an export file in the home directory.
+func defaultPlaylistPath() string {
+	name := "valvefm-favorites" + playlist.M3U.Ext()
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, name)
+	}
+	return name
+}
+
+// selectedFormat is the chosen format, or empty for auto.
+func (f playlistForm) selectedFormat() playlist.Format {
+	if f.format == 0 {
+		return ""
+	}
+	return playlist.Formats[f.format-1]
+}
+
+func (f *playlistForm) setFocus(field playlistField) {
+	f.focus = (field + playlistFieldCount) % playlistFieldCount
+	if f.focus == playlistFile {
+		f.file.Focus()
+		f.file.CursorEnd()
+	} else {
+		f.file.Blur()
+	}
+}
+
+// cycleFormat steps through the formats. On export the file extension
+// follows the chosen format.
+func (f *playlistForm) cycleFormat(step int) {
+	count := len(playlist.Formats) + 1
+	f.format = (f.format + step + count) % count
+	format := f.selectedFormat()
+	if f.importing || format == "" {
+		return
+	}
+	path := strings.TrimSpace(f.file.Value())
+	if _, err := playlist.FormatFromPath(path); err == nil {
+		path = strings.TrimSuffix(path, filepath.Ext(path))
+	}
+	f.file.SetValue(path + format.Ext())
+}
+
+func (m Model) openPlaylistForm() (tea.Model, tea.Cmd) {
+	if m.favorites == nil {
+		m.errMsg = "Favorites are not available"
+		return m, nil
+	}
+	seq := m.playlist.seq
+	file := m.playlist.file.Value()
+	m.playlist = newPlaylistForm()
+	m.playlist.seq = seq
+	if file != "" {
+		m.playlist.file.SetValue(file)
+	}
+	m.playlist.setFocus(playlistAction)
+	m.inputMode = inputPlaylist
+	return m, textinput.Blink
+}
+
+func (m Model) updatePlaylistForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if key.String() == "esc" {
+		m.inputMode = inputNone
+		m.playlist.seq++
+		m.playlistRuns.stop()
+		m.playlist.busy = false
+		m.playlist.file.Blur()
+		return m, nil
+	}
+	if m.playlist.busy {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "tab", "down":
+		m.playlist.setFocus(m.playlist.focus + 1)
+		return m, nil
+	case "shift+tab", "up":
+		m.playlist.setFocus(m.playlist.focus - 1)
+		return m, nil
+	case "enter":
+		return m.runPlaylist()
+	}
+
+	switch m.playlist.focus {
+	case playlistAction:
+		switch key.String() {
+		case "left", "right", " ":
+			m.playlist.importing = !m.playlist.importing
+			m.playlist.err = ""
+		}
+		return m, nil
+	case playlistFormat:
+		switch key.String() {
+		case "left":
+			m.playlist.cycleFormat(-1)
+		case "right", " ":
+			m.playlist.cycleFormat(1)
+		}
+		m.playlist.err = ""
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.playlist.file, cmd = m.playlist.file.Update(msg)
+	m.playlist.err = ""
+	return m, cmd
+}
+
+func (m Model) runPlaylist() (tea.Model, tea.Cmd) {
+	path := expandHome(strings.TrimSpace(m.playlist.file.Value()))
+	if path == "" {
+		m.playlist.err = "Enter a file name"
+		return m, nil
+	}
+	format := m.playlist.selectedFormat()
+	if format == "" && !m.playlist.importing {
+		detected, err := playlist.FormatFromPath(path)
+		if err != nil {
+			m.playlist.err = "Choose a format or use a .m3u, .pls, .opml, .xspf or .json file"
+			return m, nil
+		}
+		format = detected
+	}
+
+	m.playlist.seq++
+	m.playlist.busy = true
+	m.playlist.err = ""
+	ctx := m.playlistRuns.next()
+	if m.playlist.importing {
+		return m, m.importPlaylistCmd(ctx, m.playlist.seq, path, format)
+	}
+	return m, m.exportPlaylistCmd(ctx, m.playlist.seq, path, format)
+}
+
+func (m Model) exportPlaylistCmd(ctx context.Context, seq int, path string, format playlist.Format) tea.Cmd {
+	api, favorites := m.api, m.favorites
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, playlistTimeout)
+		defer cancel()
+		msg := playlistDoneMsg{seq: seq, path: path, export: true}
+		f, err := os.Create(path)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.exported, msg.err = playlist.ExportFavorites(ctx, f, format, favorites, api)
+		if closeErr := f.Close(); msg.err == nil {
+			msg.err = closeErr
+		}
+		return msg
+	}
+}
+
+// importPlaylistCmd reads path into the favorites. Closing the form cancels
+// ctx, after which the favorites are left untouched.
+func (m Model) importPlaylistCmd(ctx context.Context, seq int, path string, format playlist.Format) tea.Cmd {
+	api, favorites := m.api, m.favorites
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, playlistTimeout)
+		defer cancel()
+		msg := playlistDoneMsg{seq: seq, path: path}
+		f, err := os.Open(path)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		defer f.Close()
+		entries, err := playlist.Read(f, format)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.imported, msg.err = playlist.ImportFavorites(ctx, entries, favorites, api)
+		return msg
+	}
+}
+
+func (m Model) updatePlaylistDone(msg playlistDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.playlist.seq || m.inputMode != inputPlaylist {
+		return m, nil
+	}
+	m.playlist.busy = false
+	if msg.err != nil {
+		action := "Export"
+		if !msg.export {
+			action = "Import"
+		}
+		m.playlist.err = action + " failed: " + describeError(msg.err)
+		return m, nil
+	}
+	m.inputMode = inputNone
+	m.playlist.file.Blur()
+
+	if msg.export {
+		m.errMsg = fmt.Sprintf("Exported %d favorites to %s", msg.exported.Written, msg.path)
+		if msg.exported.Skipped > 0 {
+			m.errMsg += fmt.Sprintf(" (%d without a stream URL skipped)", msg.exported.Skipped)
+		}
+		return m, nil
+	}
+
+	result := msg.imported
+	m.errMsg = fmt.Sprintf("Imported %d favorites (%d from Radio Browser, %d custom)", result.Added(), result.Matched, result.Custom)
+	if result.Duplicates > 0 || result.Skipped > 0 {
+		m.errMsg += fmt.Sprintf("; %d already saved, %d skipped", result.Duplicates, result.Skipped)
+	}
+	if m.stationSource != sourceFavorites || result.Added() == 0 {
+		return m, nil
+	}
+	m.page = 0
+	m.hasMore = false
+	m.selected = 0
+	m.loading = true
+	return m, m.loadStationsCmd()
+}
+
+// expandHome resolves a leading ~ to the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+func (m Model) renderPlaylistForm(width int) string {
+	panelWidth := min(max(width, 10), 64)
+	lines := []string{m.styles.ListHeader.Render("Import/Export Favorites"), ""}
+
+	for field := playlistField(0); field < playlistFieldCount; field++ {
+		marker := "  "
+		style := m.styles.ListItem
+		if field == m.playlist.focus {
+			marker = "> "
+			style = m.styles.ListActive
+		}
+		label := style.Render(fmt.Sprintf("%s%-7s", marker, playlistLabels[field]))
+		lines = append(lines, label+" "+m.playlistFieldValue(field))
+	}
+
+	if m.playlist.busy {
+		status := "Exporting..."
+		if m.playlist.importing {
+			status = "Importing and matching stations on Radio Browser..."
+		}
+		lines = append(lines, "", m.styles.Accent.Render(status))
+	}
+	if m.playlist.err != "" {
+		lines = append(lines, "", m.styles.Error.Render(m.playlist.err))
+	}
+	lines = append(lines, "", m.styles.Muted.Render("Tab/Up/Down move  Left/Right change  Enter run  Esc cancel"))
+	return m.styles.Panel.Width(panelWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (m Model) playlistFieldValue(field playlistField) string {
+	switch field {
+	case playlistAction:
+		if m.playlist.importing {
+			return "< Import into favorites >"
+		}
+		return "< Export favorites >"
+	case playlistFormat:
+		format := strings.ToUpper(string(m.playlist.selectedFormat()))
+		if format == "" {
+			format = "Auto"
+		}
+		return "< " + format + " >"
+	}
+	return m.playlist.file.View()
+}
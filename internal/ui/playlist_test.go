@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+func newPlaylistTestModel(t *testing.T) Model {
+	t.Helper()
+	useTempConfigDir(t)
+	favs, err := config.LoadFavorites()
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	m := *createTestModel()
+	m.favorites = favs
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	m = updated.(Model)
+	if m.inputMode != inputPlaylist || m.playlist.focus != playlistAction {
+		t.Fatalf("mode = %v, focus = %v", m.inputMode, m.playlist.focus)
+	}
+	return m
+}
+
+func TestPlaylistForm_Export(t *testing.T) {
+	m := newPlaylistTestModel(t)
+	if _, err := m.favorites.Toggle(radio.Station{UUID: "1", Name: "Rock FM", URL: "http://rock.example.com/"}); err != nil {
+		t.Fatalf("Toggle() error = %v", err)
+	}
+	if !strings.HasSuffix(m.playlist.file.Value(), "valvefm-favorites.m3u") {
+		t.Errorf("default file = %q", m.playlist.file.Value())
+	}
+
+	m, _ = pressKey(t, m, tea.KeyTab)
+	m, _ = pressKey(t, m, tea.KeyRight)
+	m, _ = pressKey(t, m, tea.KeyRight)
+	path := m.playlist.file.Value()
+	if m.playlist.selectedFormat() != "pls" || filepath.Ext(path) != ".pls" {
+		t.Fatalf("format = %q, file = %q; the extension should follow the format", m.playlist.selectedFormat(), path)
+	}
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if !m.playlist.busy || cmd == nil {
+		t.Fatal("Enter should start the export")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.inputMode != inputNone || m.errMsg != "Exported 1 favorites to "+path {
+		t.Fatalf("mode = %v, errMsg = %q, form err = %q", m.inputMode, m.errMsg, m.playlist.err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "File1=http://rock.example.com/") {
+		t.Errorf("exported %q, %v", data, err)
+	}
+}
+
+func TestPlaylistForm_Import(t *testing.T) {
+	m := newPlaylistTestModel(t)
+	m.stationSource = sourceFavorites
+	path := filepath.Join(t.TempDir(), "vlc-list")
+	if err := os.WriteFile(path, []byte("#EXTM3U\n#EXTINF:-1,Office\nhttp://office.example.com/live\nnot a url\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	m, _ = pressKey(t, m, tea.KeyRight)
+	m, _ = pressKey(t, m, tea.KeyTab)
+	m, _ = pressKey(t, m, tea.KeyTab)
+	if !m.playlist.importing || m.playlist.focus != playlistFile {
+		t.Fatalf("importing = %v, focus = %v", m.playlist.importing, m.playlist.focus)
+	}
+	m.playlist.file.SetValue(path)
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if cmd == nil {
+		t.Fatal("Enter should start the import")
+	}
+	updated, cmd := m.Update(cmd())
+	m = updated.(Model)
+	if m.errMsg != "Imported 1 favorites (0 from Radio Browser, 1 custom); 0 already saved, 1 skipped" {
+		t.Fatalf("errMsg = %q, form err = %q", m.errMsg, m.playlist.err)
+	}
+	if m.favorites.Count() != 1 || !m.loading || cmd == nil {
+		t.Errorf("count = %d, loading = %v; the favorites list should reload", m.favorites.Count(), m.loading)
+	}
+}
+
+func TestPlaylistForm_EscCancelsImport(t *testing.T) {
+	m := newPlaylistTestModel(t)
+	m.playlistRuns = &loadScope{}
+	path := filepath.Join(t.TempDir(), "list.m3u")
+	if err := os.WriteFile(path, []byte("#EXTM3U\nhttp://office.example.com/live\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	m.playlist.importing = true
+	m.playlist.file.SetValue(path)
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if cmd == nil {
+		t.Fatal("Enter should start the import")
+	}
+	m, _ = pressKey(t, m, tea.KeyEsc)
+
+	// The import finishes after the form was closed.
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.favorites.Count() != 0 {
+		t.Errorf("count = %d; a cancelled import should not add favorites", m.favorites.Count())
+	}
+	if m.inputMode != inputNone || m.errMsg != "" {
+		t.Errorf("mode = %v, errMsg = %q", m.inputMode, m.errMsg)
+	}
+}
+
+func TestPlaylistForm_ReportsErrorsInForm(t *testing.T) {
+	m := newPlaylistTestModel(t)
+	m.playlist.file.SetValue(filepath.Join(t.TempDir(), "favorites"))
+
+	m, cmd := pressKey(t, m, tea.KeyEnter)
+	if cmd != nil || !strings.Contains(m.playlist.err, "Choose a format") {
+		t.Fatalf("err = %q; an export without a format should be refused", m.playlist.err)
+	}
+
+	m.playlist.importing = true
+	m, cmd = pressKey(t, m, tea.KeyEnter)
+	updated, _ := m.Update(cmd())
+	m = updated.(Model)
+	if m.inputMode != inputPlaylist || !strings.HasPrefix(m.playlist.err, "Import failed: ") {
+		t.Errorf("mode = %v, err = %q; a missing file should keep the form open", m.inputMode, m.playlist.err)
+	}
+}
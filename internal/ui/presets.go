@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/radio"
+)
+
+// presetShiftKeys maps shift+digit, as a US keyboard sends it, to the
+// preset stored by that key.
+var presetShiftKeys = map[string]int{
+	"!": 1, "@": 2, "#": 3, "$": 4, "%": 5,
+	"^": 6, "&": 7, "*": 8, "(": 9, ")": 0,
+}
+
+type presetsSavedMsg struct{ err error }
+
+// presetKey reports the preset recalled by a digit key.
+func presetKey(key string) (int, bool) {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return 0, false
+	}
+	return int(key[0] - '0'), true
+}
+
+// presetOf returns the preset button holding station, checking 1-9
+// before 0 as they sit on the keyboard.
+func (m Model) presetOf(uuid string) (int, bool) {
+	if uuid == "" {
+		return 0, false
+	}
+	for i := 1; i <= config.PresetCount; i++ {
+		n := i % config.PresetCount
+		if preset, ok := m.cfg.Presets[n]; ok && preset.UUID == uuid {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// storePreset puts the selected station on preset n. Storing the station
+// already on n clears the button.
+func (m Model) storePreset(n int) (tea.Model, tea.Cmd) {
+	station, ok := m.currentStation()
+	if !ok {
+		return m, nil
+	}
+	presets := maps.Clone(m.cfg.Presets)
+	if presets == nil {
+		presets = map[int]radio.Station{}
+	}
+	if presets[n].UUID == station.UUID {
+		delete(presets, n)
+		m.errMsg = fmt.Sprintf("Cleared preset %d", n)
+	} else {
+		presets[n] = station
+		m.errMsg = fmt.Sprintf("Stored %s on preset %d", station.Name, n)
+	}
+	m.cfg.Presets = presets
+	return m, savePresetsCmd(presets)
+}
+
+// recallPreset tunes to the station on preset n and plays it.
+func (m Model) recallPreset(n int) (tea.Model, tea.Cmd) {
+	cmd, err := m.playPreset(n)
+	if err != nil {
+		m.errMsg = err.Error()
+		return m, nil
+	}
+	return m, cmd
+}
+
+// playPreset selects the preset's station when it is listed and starts
+// playback; the stored copy is played either way.
+func (m *Model) playPreset(n int) (tea.Cmd, error) {
+	station, ok := m.cfg.Presets[n]
+	if !ok {
+		return nil, fmt.Errorf("preset %d is empty; select a station and press shift+%d to store it", n, n)
+	}
+	for i, listed := range m.visibleStations() {
+		if listed.UUID == station.UUID {
+			m.selected = i
+			m.dialTarget = m.dialValueForIndex(i)
+			break
+		}
+	}
+	m.errMsg = ""
+	return tea.Batch(m.dialTickCmd(), m.playStationCmd(station)), nil
+}
+
+func (m *Model) ipcPreset(arg string) (tea.Cmd, ipcReply) {
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil || n < 0 || n >= config.PresetCount {
+		return nil, ipcReply{ok: false, err: "preset must be 0-9"}
+	}
+	cmd, err := m.playPreset(n)
+	if err != nil {
+		return nil, ipcReply{ok: false, err: fmt.Sprintf("preset %d is empty", n)}
+	}
+	return cmd, ipcReply{ok: true, data: "QUEUED"}
+}
+
+func savePresetsCmd(presets map[int]radio.Station) tea.Cmd {
+	return func() tea.Msg {
+		return presetsSavedMsg{err: config.SavePresets(presets)}
+	}
+}
+
+// presetMarks puts the preset numbers of listed stations under their spot
+// on a dial bar of the given width.
+func (m Model) presetMarks(width int) []byte {
+	marks := []byte(strings.Repeat(" ", width))
+	if len(m.cfg.Presets) == 0 {
+		return marks
+	}
+	for i, station := range m.visibleStations() {
+		n, ok := m.presetOf(station.UUID)
+		if !ok {
+			continue
+		}
+		pos := m.dialPosition(m.dialValueForIndex(i), width)
+		marks[pos] = byte('0' + n)
+	}
+	return marks
+}
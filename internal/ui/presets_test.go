@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/config"
+)
+
+func TestPresets_StoreAndRecall(t *testing.T) {
+	useTempConfigDir(t)
+	m := *createTestModel()
+	m.updateDialRange()
+	m.snapDial()
+
+	m.selected = 2
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	m = updated.(Model)
+	if m.cfg.Presets[3].UUID != "3" || m.errMsg != "Stored Jazz Station on preset 3" || cmd == nil {
+		t.Fatalf("presets = %+v, errMsg = %q", m.cfg.Presets, m.errMsg)
+	}
+	if msg := cmd().(presetsSavedMsg); msg.err != nil {
+		t.Fatalf("saving presets failed: %v", msg.err)
+	}
+	if saved := config.LoadConfig().Presets[3]; saved.Name != "Jazz Station" {
+		t.Errorf("saved preset = %+v", saved)
+	}
+
+	if list := m.renderList(60, 5); !strings.Contains(list, "Jazz Station [3]") {
+		t.Errorf("list should show the preset number:\n%s", list)
+	}
+	if dial := m.renderDial(60, false, false); !strings.Contains(dial, "P3") {
+		t.Errorf("dial should show the preset of the selected station:\n%s", dial)
+	}
+
+	m.selected = 0
+	m.snapDial()
+	if pointer := m.pointerLine(strings.Repeat("-", 60)); !strings.Contains(pointer, "3") || !strings.HasPrefix(strings.TrimSpace(pointer), "^") {
+		t.Errorf("pointer line %q should mark preset 3 on the band", pointer)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	m = updated.(Model)
+	if m.selected != 2 || cmd == nil {
+		t.Fatalf("selected = %d; recalling a preset should tune to it and play", m.selected)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	m = updated.(Model)
+	if _, ok := m.cfg.Presets[3]; ok || m.errMsg != "Cleared preset 3" {
+		t.Errorf("storing the same station again should clear the preset: %+v, %q", m.cfg.Presets, m.errMsg)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	m = updated.(Model)
+	if cmd != nil || !strings.Contains(m.errMsg, "preset 5 is empty") {
+		t.Errorf("errMsg = %q", m.errMsg)
+	}
+}
+
+func TestPresets_IPC(t *testing.T) {
+	useTempConfigDir(t)
+	m := *createTestModel()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{')'}})
+	m = updated.(Model)
+	m.selected = 3
+
+	tests := []struct {
+		cmd  string
+		want ipcReply
+	}{
+		{"preset 0", ipcReply{ok: true, data: "QUEUED"}},
+		{"PRESET 4", ipcReply{ok: false, err: "preset 4 is empty"}},
+		{"PRESET 12", ipcReply{ok: false, err: "preset must be 0-9"}},
+	}
+	for _, tt := range tests {
+		reply := make(chan ipcReply, 1)
+		updated, _ := m.handleIPC(ipcMsg{cmd: tt.cmd, reply: reply})
+		if got := <-reply; got != tt.want {
+			t.Errorf("%s: reply = %+v, want %+v", tt.cmd, got, tt.want)
+		}
+		if tt.want.ok && updated.(Model).selected != 0 {
+			t.Errorf("%s: selected = %d, want the preset station", tt.cmd, updated.(Model).selected)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/player"
+	"radio-tui/internal/radio"
+)
+
+// streamCheckedMsg reports that one more stream check finished; the result
+// itself lives in the model's Checker.
+type streamCheckedMsg struct {
+	updates <-chan tea.Msg
+}
+
+func newStreamChecker() *player.Checker {
+	return player.NewChecker(player.CheckerOptions{})
+}
+
+// toggleStreamChecks turns checking of the listed streams on or off for the
+// session.
+func (m Model) toggleStreamChecks() (tea.Model, tea.Cmd) {
+	m.checkStreams = !m.checkStreams
+	if !m.checkStreams {
+		m.streamChecks.stop()
+		m.errMsg = "Stream checks off"
+		return m, nil
+	}
+	m.errMsg = "Checking streams of the listed stations..."
+	return m, m.checkStreamsCmd()
+}
+
+// checkStreamsCmd checks every station on the current page that has no
+// fresh result, abandoning checks for a previous page.
+func (m Model) checkStreamsCmd() tea.Cmd {
+	if !m.checkStreams || m.checker == nil {
+		return nil
+	}
+	urls := make([]string, 0, len(m.stations))
+	for _, station := range m.visibleStations() {
+		if url := stationStreamURL(station); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	ctx := m.streamChecks.next()
+	checker := m.checker
+	return func() tea.Msg {
+		updates := make(chan tea.Msg, 1)
+		go func() {
+			defer close(updates)
+			checker.CheckAll(ctx, urls, func(string, player.CheckResult) {
+				// One pending redraw is enough; the view reads the cache.
+				select {
+				case updates <- streamCheckedMsg{updates: updates}:
+				default:
+				}
+			})
+		}()
+		return waitUpdatesCmd(updates)()
+	}
+}
+
+// streamCheck returns the cached check for station, if stream checks are on.
+func (m Model) streamCheck(station radio.Station) (player.CheckResult, bool) {
+	if !m.checkStreams {
+		return player.CheckResult{}, false
+	}
+	return m.checker.Cached(stationStreamURL(station))
+}
+
+// streamCheckNote is the reason shown next to a slow or dead station.
+func streamCheckNote(result player.CheckResult) string {
+	switch result.Status {
+	case player.StreamDead:
+		return fmt.Sprintf(" [dead: %s]", result.Reason)
+	case player.StreamSlow:
+		return fmt.Sprintf(" [%s]", result.Reason)
+	}
+	return ""
+}
+
+func stationStreamURL(station radio.Station) string {
+	url, err := radio.StreamURL(station)
+	if err != nil {
+		return ""
+	}
+	return url
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"radio-tui/internal/radio"
+)
+
+// drainStreamChecks feeds check updates back into the model until the
+// checks finish.
+func drainStreamChecks(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	for cmd != nil {
+		msg := cmd()
+		if msg == nil {
+			break
+		}
+		if _, ok := msg.(streamCheckedMsg); !ok {
+			t.Fatalf("unexpected message %T", msg)
+		}
+		var updated tea.Model
+		updated, cmd = m.Update(msg)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestModel_StreamChecks_MarkDeadStations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/live" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte(strings.Repeat("\xff", 2048)))
+	}))
+	defer server.Close()
+
+	m := *createTestModel()
+	m.checker = newStreamChecker()
+	m.stations = []radio.Station{
+		{UUID: "1", Name: "Live FM", URLResolved: server.URL + "/live"},
+		{UUID: "2", Name: "Gone FM", URLResolved: server.URL + "/gone"},
+	}
+
+	if view := m.renderList(60, 10); strings.Contains(view, "[dead") {
+		t.Fatalf("nothing should be marked before checks are enabled:\n%s", view)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(Model)
+	if !m.checkStreams || cmd == nil {
+		t.Fatalf("checkStreams = %v, cmd = %v", m.checkStreams, cmd)
+	}
+	m = drainStreamChecks(t, m, cmd)
+
+	view := m.renderList(60, 10)
+	if !strings.Contains(view, "Gone FM [dead: HTTP 404]") {
+		t.Errorf("dead station should show its reason:\n%s", view)
+	}
+	if strings.Contains(view, "Live FM [") {
+		t.Errorf("a live station should not be marked:\n%s", view)
+	}
+
+	// Reloading the same page reuses the cached results.
+	updated, cmd = m.Update(stationsMsg{stations: m.stations, source: m.stationSource, page: m.page, country: m.country, query: m.activeQueryKey()})
+	m = updated.(Model)
+	if msg := runBatch(cmd); len(msg) != 1 || msg[0] != nil {
+		t.Errorf("cached stations should not be checked again, got %v", msg)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = updated.(Model)
+	if view := m.renderList(60, 10); strings.Contains(view, "[dead") {
+		t.Errorf("turning checks off should hide the marks:\n%s", view)
+	}
+}
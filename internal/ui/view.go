@@ -6,6 +6,10 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"radio-tui/internal/config"
+	"radio-tui/internal/player"
+	"radio-tui/internal/radio"
 )
 
 func (m Model) View() string {
@@ -48,6 +52,12 @@ func (m Model) View() string {
 	if m.inputMode == inputSearch {
 		prompt = m.styles.Panel.Width(contentWidth).Render(m.search.View())
 	}
+	if m.inputMode == inputNearby {
+		prompt = m.styles.Panel.Width(contentWidth).Render(m.nearbyInput.View())
+	}
+	if m.inputMode == inputGroup {
+		prompt = m.styles.Panel.Width(contentWidth).Render(m.groupInput.View())
+	}
 
 	appPadding := 2
 	baseHeight := lipgloss.Height(header) + lipgloss.Height(dial) + lipgloss.Height(meta) + lipgloss.Height(keyHints)
@@ -96,6 +106,30 @@ func (m Model) View() string {
 		selector := m.renderCountrySelect(contentWidth, m.height)
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, selector)
 	}
+	if m.inputMode == inputAddStation {
+		form := m.renderAddStationForm(contentWidth)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
+	}
+	if m.inputMode == inputPlaylist {
+		form := m.renderPlaylistForm(contentWidth)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
+	}
+	if m.inputMode == inputRecent {
+		picker := m.renderRecentPicker(contentWidth)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, picker)
+	}
+	if m.inputMode == inputChart {
+		picker := m.renderChartPicker(contentWidth)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, picker)
+	}
+	if m.inputMode == inputBrowse {
+		selector := m.renderBrowseSelect(contentWidth, m.height)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, selector)
+	}
+	if m.inputMode == inputFilter {
+		form := m.renderFilterForm(contentWidth)
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
+	}
 
 	return view
 }
@@ -113,18 +147,48 @@ func (m Model) renderHeader(width int) string {
 	if m.isFavoritesSource() {
 		source = "FAVORITES"
 	}
+	switch m.stationSource {
+	case sourceFilter:
+		source = "FILTER"
+	case sourceTag:
+		source = "#" + strings.ToUpper(m.activeBrowse)
+	case sourceLanguage:
+		source = strings.ToUpper(m.activeBrowse)
+	case sourceWorld:
+		source = "WORLD"
+	case sourceCharts:
+		source = "CHARTS"
+	case sourceNearby:
+		source = "NEARBY"
+	case sourceHistory:
+		source = "HISTORY"
+	}
 	if width >= 30 {
 		left = fmt.Sprintf("VALVE FM [%s] FM STEREO", source)
 	} else if width >= 20 {
 		left = fmt.Sprintf("VALVE FM [%s]", source)
 	}
 	right := statusStyle.Render(status)
+	if badge := m.cacheBadge(); badge != "" && !m.isFavoritesSource() && m.stationSource != sourceHistory {
+		right = m.styles.Muted.Render(badge) + " " + right
+	}
 	line := joinHeader(left, right, width)
 	return m.styles.Header.Width(width).Render(line)
 }
 
+// cacheBadge labels station lists that were not freshly fetched from the API.
+func (m Model) cacheBadge() string {
+	switch {
+	case m.listStale:
+		return "OFFLINE"
+	case m.listCached:
+		return "CACHED"
+	}
+	return ""
+}
+
 func (m Model) renderDial(width int, compact bool, tiny bool) string {
-	labels, bar, minor := buildDialScale(width, m.dialMin, m.dialMax, m.dialUseFreq)
+	labels, bar, minor := buildDialScale(width, m.dialMin, m.dialMax, m.dialUseFreq || m.dialUseDistance)
 	ptrLine := m.pointerLine(bar)
 	freq, exact := m.selectedFrequency()
 	freqPrefix := ""
@@ -132,6 +196,20 @@ func (m Model) renderDial(width int, compact bool, tiny bool) string {
 		freqPrefix = "~"
 	}
 	freqLine := fmt.Sprintf("%s%.1f MHz", freqPrefix, freq)
+	if m.dialUseDistance {
+		freqLine = "Distance: -"
+		if station, ok := m.currentStation(); ok {
+			if distance, ok := m.stationDistance(station); ok {
+				freqLine = fmt.Sprintf("%.1f km", distance)
+			}
+		}
+	}
+
+	if station, ok := m.currentStation(); ok {
+		if n, ok := m.presetOf(station.UUID); ok {
+			freqLine += fmt.Sprintf("  P%d", n)
+		}
+	}
 
 	lines := []string{}
 	showLabels := width >= 28
@@ -140,6 +218,12 @@ func (m Model) renderDial(width int, compact bool, tiny bool) string {
 		if compact {
 			title = "FM"
 		}
+		if m.dialUseDistance {
+			title = "DISTANCE (KM)"
+			if compact {
+				title = "KM"
+			}
+		}
 		lines = append(lines, m.styles.DialLabel.Render(title))
 	}
 	if showLabels {
@@ -155,27 +239,35 @@ func (m Model) renderDial(width int, compact bool, tiny bool) string {
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
+// pointerLine draws the dial pointer with the numbers of preset stations
+// marked along the band.
 func (m Model) pointerLine(bar string) string {
 	pos := max(m.pointerPosition(bar), 0)
 	if pos >= len(bar) {
 		pos = len(bar) - 1
 	}
-	return strings.Repeat(" ", pos) + "^"
+	line := m.presetMarks(len(bar))
+	line[pos] = '^'
+	return strings.TrimRight(string(line), " ")
 }
 
 func (m Model) pointerPosition(bar string) int {
-	list := m.visibleStations()
-	if len(list) <= 1 {
+	if len(m.visibleStations()) <= 1 {
 		return 0
 	}
+	return m.dialPosition(m.dialPos, len(bar))
+}
+
+// dialPosition maps a dial value to a column of a bar width columns wide.
+func (m Model) dialPosition(value float64, width int) int {
+	list := m.visibleStations()
 	var pos float64
-	if m.dialUseFreq && m.dialMax > m.dialMin {
-		pos = (m.dialPos - m.dialMin) / (m.dialMax - m.dialMin)
-	} else {
-		maxIndex := len(list) - 1
-		pos = m.dialPos / float64(maxIndex)
+	if (m.dialUseFreq || m.dialUseDistance) && m.dialMax > m.dialMin {
+		pos = (value - m.dialMin) / (m.dialMax - m.dialMin)
+	} else if len(list) > 1 {
+		pos = value / float64(len(list)-1)
 	}
-	return int(math.Round(pos * float64(len(bar)-1)))
+	return min(max(int(math.Round(pos*float64(width-1))), 0), width-1)
 }
 
 func (m Model) selectedFrequency() (float64, bool) {
@@ -211,23 +303,112 @@ func (m Model) renderStationMeta() string {
 	if station.Bitrate <= 0 {
 		bitrate = "Bitrate: -"
 	}
+	if codec := streamFormat(station); codec != "" {
+		bitrate += " " + codec
+	}
 	status := "Status: STOPPED"
 	if m.playing && station.UUID == m.playingUUID {
 		status = "Status: LIVE"
+		if m.playingBackend != "" {
+			status += fmt.Sprintf(" (%s)", m.playingBackend)
+		}
 	}
 	country := fmt.Sprintf("Country: %s", fallback(station.Country, "-"))
+	if strings.TrimSpace(station.State) != "" {
+		country += " / " + station.State
+	}
 
 	lines := []string{
 		name,
 		m.styles.Meta.Render(country),
+	}
+	if strings.TrimSpace(station.Language) != "" {
+		lines = append(lines, m.styles.Meta.Render("Language: "+station.Language))
+	}
+	lines = append(lines,
 		m.styles.Meta.Render(tags),
 		m.styles.Meta.Render(bitrate),
-		m.styles.Meta.Render(status),
+	)
+	if distance, ok := m.stationDistance(station); ok && m.stationSource == sourceNearby {
+		lines = append(lines, m.styles.Meta.Render(fmt.Sprintf("Distance: %.1f km", distance)))
+	}
+	if popularity := stationPopularity(station); popularity != "" {
+		lines = append(lines, m.styles.Meta.Render(popularity))
+	}
+	if strings.TrimSpace(station.Homepage) != "" {
+		lines = append(lines, m.styles.Meta.Render("Homepage: "+station.Homepage))
+	}
+	lines = append(lines, m.styles.Meta.Render(status))
+	for _, line := range m.historyMeta(station) {
+		lines = append(lines, m.styles.Meta.Render(line))
+	}
+	if station.Custom() {
+		lines = append(lines, m.styles.Muted.Render("Custom station, not listed on Radio Browser"))
+	}
+	if station.LastCheckFailed() {
+		lines = append(lines, m.styles.Error.Render(lastCheckWarning))
+	}
+	if m.favoriteMissing(station.UUID) {
+		lines = append(lines, m.styles.Error.Render(missingFavoriteWarning))
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, lines...)
 }
 
+const (
+	lastCheckWarning       = "Warning: last check failed, stream may be offline"
+	missingFavoriteWarning = "Warning: station is no longer listed on Radio Browser"
+)
+
+// favoriteMissing reports whether the last favorites refresh could not find
+// the station.
+func (m Model) favoriteMissing(uuid string) bool {
+	if m.favorites == nil {
+		return false
+	}
+	fav, ok := m.favorites.Get(uuid)
+	return ok && fav.Missing
+}
+
+// favoriteFlagged reports whether a favorite is marked "!" in the list: it
+// counts as broken, as in the favorites refresh summary, or is no longer
+// listed.
+func (m Model) favoriteFlagged(station radio.Station) bool {
+	if m.favorites == nil {
+		return false
+	}
+	fav, ok := m.favorites.Get(station.UUID)
+	if !ok {
+		return false
+	}
+	// The listed record may be fresher than the stored snapshot.
+	return fav.Missing || fav.Broken() || station.IsBroken || station.LastCheckFailed()
+}
+
+// streamFormat describes the codec and transport, e.g. "AAC HLS".
+func streamFormat(station radio.Station) string {
+	parts := []string{}
+	if codec := strings.TrimSpace(station.Codec); codec != "" && !strings.EqualFold(codec, "unknown") {
+		parts = append(parts, strings.ToUpper(codec))
+	}
+	if station.HLS.True() {
+		parts = append(parts, "HLS")
+	}
+	return strings.Join(parts, " ")
+}
+
+// stationPopularity summarises votes and clicks, including the click trend.
+func stationPopularity(station radio.Station) string {
+	if station.Votes == 0 && station.ClickCount == 0 && station.ClickTrend == 0 {
+		return ""
+	}
+	line := fmt.Sprintf("Votes: %d | Clicks: %d", station.Votes, station.ClickCount)
+	if station.ClickTrend != 0 {
+		line += fmt.Sprintf(" (%+d)", station.ClickTrend)
+	}
+	return line
+}
+
 func (m Model) renderStationMetaCompact(width int, tiny bool) string {
 	station, ok := m.currentStation()
 	if !ok {
@@ -246,6 +427,9 @@ func (m Model) renderStationMetaCompact(width int, tiny bool) string {
 	}
 
 	line1 := m.styles.StationName.Render(name)
+	if station.LastCheckFailed() {
+		status += " | CHECK FAILED"
+	}
 	meta := fmt.Sprintf("Tags: %s | %s", fallback(station.Tags, "-"), status)
 	meta = truncateText(meta, max(width-6, 12))
 	line2 := m.styles.Meta.Render(meta)
@@ -254,15 +438,35 @@ func (m Model) renderStationMetaCompact(width int, tiny bool) string {
 
 func (m Model) renderList(width int, maxItems int) string {
 	list := m.visibleStations()
-	header := fmt.Sprintf("Stations (Page %d)", m.page+1)
+	header := fmt.Sprintf("Stations (%s)", m.pageLabel())
 	if m.isFavoritesSource() {
-		header = fmt.Sprintf("Favorites (Page %d)", m.page+1)
+		header = fmt.Sprintf("%s (%s)", m.favoritesTitle(), m.pageLabel())
+	}
+	switch m.stationSource {
+	case sourceFilter:
+		header = fmt.Sprintf("Filter: %s (%s)", describeFilter(m.activeFilter), m.pageLabel())
+	case sourceTag:
+		header = fmt.Sprintf("Tag: %s (%s)", m.activeBrowse, m.pageLabel())
+	case sourceLanguage:
+		header = fmt.Sprintf("Language: %s (%s)", m.activeBrowse, m.pageLabel())
+	case sourceWorld:
+		header = fmt.Sprintf("Worldwide (%s)", m.pageLabel())
+	case sourceCharts:
+		header = fmt.Sprintf("%s (%s)", m.chartTitle(), m.pageLabel())
+	case sourceNearby:
+		header = fmt.Sprintf("%s (%s)", m.nearbyTitle(), m.pageLabel())
+	case sourceHistory:
+		header = fmt.Sprintf("%s (%s)", m.historyOrder.title(), m.pageLabel())
 	}
 	if strings.TrimSpace(m.activeSearch) != "" {
 		if m.isFavoritesSource() {
-			header = fmt.Sprintf("Favorites Search: %q (Page %d)", m.activeSearch, m.page+1)
+			header = fmt.Sprintf("%s Search: %q (%s)", m.favoritesTitle(), m.activeSearch, m.pageLabel())
+		} else if m.stationSource == sourceHistory {
+			header = fmt.Sprintf("%s Search: %q (%s)", m.historyOrder.title(), m.activeSearch, m.pageLabel())
+		} else if m.stationSource == sourceWorld {
+			header = fmt.Sprintf("Worldwide Search: %q (%s)", m.activeSearch, m.pageLabel())
 		} else {
-			header = fmt.Sprintf("Search: %q (Page %d)", m.activeSearch, m.page+1)
+			header = fmt.Sprintf("Search: %q (%s)", m.activeSearch, m.pageLabel())
 		}
 	}
 	lines := []string{m.styles.ListHeader.Render(header)}
@@ -271,6 +475,8 @@ func (m Model) renderList(width int, maxItems int) string {
 		label := "Loading stations..."
 		if m.isFavoritesSource() {
 			label = "Loading favorites..."
+		} else if m.stationSource == sourceHistory {
+			label = "Loading history..."
 		}
 		lines = append(lines, m.styles.Muted.Render(label))
 		return m.styles.Panel.Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
@@ -280,6 +486,8 @@ func (m Model) renderList(width int, maxItems int) string {
 		label := "No stations found"
 		if m.isFavoritesSource() {
 			label = "No favorites found"
+		} else if m.stationSource == sourceHistory {
+			label = "Nothing played yet"
 		}
 		lines = append(lines, m.styles.Muted.Render(label))
 		return m.styles.Panel.Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
@@ -294,6 +502,11 @@ func (m Model) renderList(width int, maxItems int) string {
 		lineWidth = width
 	}
 	showFreq := lineWidth >= 32
+	showCountry := m.listSpansCountries()
+	var listened map[string]config.StationStats
+	if m.stationSource == sourceHistory {
+		listened = m.historyTotals()
+	}
 
 	for i := start; i < end; i++ {
 		station := list[i]
@@ -307,9 +520,39 @@ func (m Model) renderList(width int, maxItems int) string {
 		fav := ""
 		if m.favorites != nil && m.favorites.IsFavorite(station.UUID) {
 			fav = " *"
+			if m.favoriteFlagged(station) {
+				fav += "!"
+			}
+		}
+		if showCountry && station.CountryCode != "" {
+			fav = " " + strings.ToUpper(station.CountryCode) + fav
+		}
+		if n, ok := m.presetOf(station.UUID); ok {
+			fav = fmt.Sprintf(" [%d]", n) + fav
+		}
+		if stats, ok := listened[station.UUID]; ok {
+			fav = " " + formatListened(stats.Total) + fav
+		}
+		if check, ok := m.streamCheck(station); ok && check.Status != player.StreamOK {
+			fav = streamCheckNote(check) + fav
+			if i != m.selected {
+				style = m.styles.Muted
+			}
 		}
 
 		name := station.Name
+		if m.stationSource == sourceNearby {
+			distance := "      -"
+			if km, ok := m.stationDistance(station); ok {
+				distance = fmt.Sprintf("%7s", formatDistance(km))
+			}
+			reserved := 2 + 7 + 1 + len(fav)
+			nameWidth := max(lineWidth-reserved, 4)
+			name = truncateText(name, nameWidth)
+			line := fmt.Sprintf("%s%s %s%s", marker, distance, name, fav)
+			lines = append(lines, style.Width(lineWidth).MaxWidth(lineWidth).Render(line))
+			continue
+		}
 		if showFreq {
 			freq, exact := m.listFrequency(i, len(list), station.Frequency.Float64())
 			prefix := " "
@@ -334,6 +577,22 @@ func (m Model) renderList(width int, maxItems int) string {
 	return m.styles.Panel.Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
+// listSpansCountries reports whether the list may mix countries, in which
+// case each row shows its country code.
+func (m Model) listSpansCountries() bool {
+	switch m.stationSource {
+	case sourceWorld, sourceTag, sourceLanguage:
+		return true
+	case sourceFilter:
+		return m.activeFilter.CountryCode == ""
+	case sourceCharts:
+		return m.chartCountry == ""
+	case sourceNearby, sourceHistory:
+		return true
+	}
+	return false
+}
+
 func (m Model) renderKeyHints(width int) string {
 	if width < 30 {
 		return "Enter Play  Q Quit"
@@ -357,9 +616,27 @@ func (m Model) renderHelp() string {
 		"Space        Stop/Resume",
 		"[ / ]        Previous/Next stations page",
 		"L            Choose country",
-		"V            Show favorites",
+		"W            Toggle worldwide stations / search",
+		"A            Stations near a position (lat, long)",
+		"C            Charts: most played, voted, trending, recent (worldwide or country)",
+		"G            Browse stations by tag (worldwide)",
+		"N            Browse stations by language (worldwide)",
+		"V            Show favorites; again (or Tab/Shift+Tab) to switch group",
+		"< / >        Move favorite up/down (also Shift+Up/Down)",
+		"M            File favorite under a group (Tab completes, empty ungroups)",
 		"/            Search stations (country API or local favorites)",
 		"F            Favorite station",
+		"+            Vote for station",
+		"S            Search with filters (tags, language, bitrate, order)",
+		"I            Add a station to Radio Browser or as a custom favorite",
+		"E            Import/export favorites as M3U, PLS, OPML, XSPF or JSON",
+		"X            Check listed streams and grey out dead or slow ones",
+		"R            Retry after a network error, timeout or busy server",
+		"1-9, 0       Play preset",
+		"Shift+1-0    Store station on preset (again to clear)",
+		"H            Listening history; again to sort by time listened",
+		"B            Recently played stations (1-0 to play)",
+		"D D          Clear listening history",
 		"T            Change theme",
 		"?            Close help",
 		"Q            Quit",
@@ -367,7 +644,7 @@ func (m Model) renderHelp() string {
 	if m.missingPlayer {
 		lines = append(lines, "", "Audio player not found.")
 		if m.downloadingPlayer {
-			lines = append(lines, "Downloading ffplay in the background...")
+			lines = append(lines, m.downloadStatus())
 		} else {
 			lines = append(lines, "Install mpv or ffplay and ensure it is in PATH.")
 		}
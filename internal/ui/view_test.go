@@ -1,6 +1,11 @@
 package ui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"radio-tui/internal/radio"
+)
 
 func TestTruncateText(t *testing.T) {
 	tests := []struct {
@@ -310,3 +315,91 @@ func TestBuildDialScaleFixed(t *testing.T) {
 		t.Error("labels should not be empty")
 	}
 }
+
+func TestRenderStationMeta_ShowsActiveBackend(t *testing.T) {
+	m := createTestModel()
+	m.styles = BuildStyles(Themes[0])
+	m.playing = true
+	m.playingUUID = "1"
+	m.playingBackend = "mpv"
+
+	meta := m.renderStationMeta()
+	if !strings.Contains(meta, "LIVE (mpv)") {
+		t.Errorf("renderStationMeta() should show active backend, got %q", meta)
+	}
+
+	m.selected = 1
+	meta = m.renderStationMeta()
+	if strings.Contains(meta, "mpv") {
+		t.Errorf("renderStationMeta() should only show backend for playing station, got %q", meta)
+	}
+}
+
+func TestRenderHeader_CacheBadge(t *testing.T) {
+	m := createTestModel()
+	m.styles = BuildStyles(Themes[0])
+
+	if header := m.renderHeader(60); strings.Contains(header, "CACHED") || strings.Contains(header, "OFFLINE") {
+		t.Errorf("renderHeader() should not show a cache badge for live data, got %q", header)
+	}
+
+	m.listCached = true
+	if header := m.renderHeader(60); !strings.Contains(header, "CACHED") {
+		t.Errorf("renderHeader() should show CACHED, got %q", header)
+	}
+
+	m.listStale = true
+	if header := m.renderHeader(60); !strings.Contains(header, "OFFLINE") {
+		t.Errorf("renderHeader() should show OFFLINE for stale data, got %q", header)
+	}
+}
+
+func TestRenderStationMeta_FullSchema(t *testing.T) {
+	m := createTestModel()
+	m.styles = BuildStyles(Themes[0])
+	m.stations[0].State = "Texas"
+	m.stations[0].Language = "english"
+	m.stations[0].Codec = "aac"
+	m.stations[0].HLS = radio.FlagTrue
+	m.stations[0].Votes = 42
+	m.stations[0].ClickCount = 100
+	m.stations[0].ClickTrend = 3
+	m.stations[0].Homepage = "https://rock.fm"
+
+	meta := m.renderStationMeta()
+	for _, want := range []string{"Texas", "Language: english", "AAC HLS", "Votes: 42 | Clicks: 100 (+3)", "https://rock.fm"} {
+		if !strings.Contains(meta, want) {
+			t.Errorf("renderStationMeta() missing %q in %q", want, meta)
+		}
+	}
+	if strings.Contains(meta, "last check failed") {
+		t.Error("renderStationMeta() should not warn without a failed check")
+	}
+
+	m.stations[0].LastCheckOK = radio.FlagFalse
+	if meta := m.renderStationMeta(); !strings.Contains(meta, lastCheckWarning) {
+		t.Errorf("renderStationMeta() should warn about failed check, got %q", meta)
+	}
+	if meta := m.renderStationMetaCompact(60, false); !strings.Contains(meta, "CHECK FAILED") {
+		t.Errorf("renderStationMetaCompact() should flag failed check, got %q", meta)
+	}
+}
+
+func TestRenderList_FlagsBrokenFavorites(t *testing.T) {
+	m := newFavoritesTestModel(t,
+		radio.Station{UUID: "1", Name: "Rock FM", IsBroken: true},
+		radio.Station{UUID: "2", Name: "Pop Radio"},
+	)
+	m.styles = BuildStyles(Themes[0])
+	if !m.favorites.List()[0].Broken() {
+		t.Fatal("the stored favorite should count as broken")
+	}
+
+	list := m.renderList(80, 10)
+	if !strings.Contains(list, "Rock FM *!") {
+		t.Errorf("broken favorite should be flagged, got %q", list)
+	}
+	if strings.Contains(list, "Pop Radio *!") {
+		t.Errorf("working favorite should not be flagged, got %q", list)
+	}
+}